@@ -0,0 +1,37 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+)
+
+// Benchmark planting in process with different job counts, without the cost of starting the binary
+func BenchmarkPlantJobs(b *testing.B) {
+	tree, err := seed.Parse(context.Background(), strings.NewReader(generateTreeStructure(ExtraLargeSize)), seed.FormatTree)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, jobs := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("5000 Nodes - %d jobs", jobs), func(b *testing.B) {
+			dest := b.TempDir()
+			for i := 0; i < b.N; i++ {
+				if _, err := seed.Plant(context.Background(), tree, dest, seed.WithJobs(jobs)); err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				if err := os.RemoveAll(filepath.Join(dest, "monorepo")); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/clipboard"
+)
+
+type ClipboardProvider string
+
+func (p ClipboardProvider) String() string {
+	return string(p)
+}
+
+func (p *ClipboardProvider) Set(value string) error {
+	if !clipboard.Lookup(value) {
+		return fmt.Errorf("invalid clipboard provider %q, must be one of: %s", value, strings.Join(clipboard.Names(), ", "))
+	}
+	*p = ClipboardProvider(value)
+	return nil
+}
+
+func (p ClipboardProvider) Type() string {
+	return "provider"
+}
@@ -0,0 +1,33 @@
+package flags
+
+import "fmt"
+
+type Conflict string
+
+var Conflicts = struct {
+	Overwrite Conflict
+	Skip      Conflict
+	Error     Conflict
+}{
+	Overwrite: "overwrite",
+	Skip:      "skip",
+	Error:     "error",
+}
+
+func (c Conflict) String() string {
+	return string(c)
+}
+
+func (c *Conflict) Set(value string) error {
+	switch Conflict(value) {
+	case Conflicts.Overwrite, Conflicts.Skip, Conflicts.Error:
+		*c = Conflict(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid conflict policy %q, must be one of: overwrite, skip, error", value)
+	}
+}
+
+func (c Conflict) Type() string {
+	return "policy"
+}
@@ -0,0 +1,6 @@
+package flags
+
+type ConvertFlags struct {
+	To    Format
+	ASCII bool
+}
@@ -0,0 +1,6 @@
+package flags
+
+type DiffFlags struct {
+	Output      string
+	IgnoreExtra bool
+}
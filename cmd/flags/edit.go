@@ -0,0 +1,6 @@
+package flags
+
+type EditFlags struct {
+	// Target is the directory the edited tree is compared against
+	Target string
+}
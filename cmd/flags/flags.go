@@ -1,5 +1,17 @@
 package flags
 
 type Flags struct {
-	Root RootFlags
+	Root     RootFlags
+	Plant    PlantFlags
+	Harvest  HarvestFlags
+	Convert  ConvertFlags
+	Fmt      FmtFlags
+	Diff     DiffFlags
+	Verify   VerifyFlags
+	Template TemplateFlags
+	Undo     UndoFlags
+	Edit     EditFlags
+	Lint     LintFlags
+	Output   OutputFlags
+	Ignore   IgnoreFlags
 }
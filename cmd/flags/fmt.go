@@ -0,0 +1,7 @@
+package flags
+
+type FmtFlags struct {
+	Check bool
+	Write bool
+	Sort  bool
+}
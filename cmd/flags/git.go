@@ -0,0 +1,52 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitActions is the comma separated list of --git, each action at most once
+type GitActions []string
+
+var Git = struct {
+	Init   string
+	Add    string
+	Commit string
+}{
+	Init:   "init",
+	Add:    "add",
+	Commit: "commit",
+}
+
+func (g GitActions) String() string {
+	return strings.Join(g, ",")
+}
+
+func (g *GitActions) Set(value string) error {
+	for _, action := range strings.Split(value, ",") {
+		action = strings.TrimSpace(action)
+		switch action {
+		case Git.Init, Git.Add, Git.Commit:
+			if !g.Has(action) {
+				*g = append(*g, action)
+			}
+		default:
+			return fmt.Errorf("invalid git action %q, must be one of: init, add, commit", action)
+		}
+	}
+	return nil
+}
+
+func (g GitActions) Type() string {
+	return "actions"
+}
+
+// Has reports whether action was given.
+func (g GitActions) Has(action string) bool {
+	for _, a := range g {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,7 @@
+package flags
+
+type HarvestFlags struct {
+	All bool
+	// GitRef harvests a git tree-ish instead of the working tree
+	GitRef string
+}
@@ -0,0 +1,12 @@
+package flags
+
+// IgnoreFlags are shared by the commands that read an existing directory.
+type IgnoreFlags struct {
+	// NoIgnore reads the directory without its .gitignore and .seedignore files
+	NoIgnore bool
+	Include  []string
+	Exclude  []string
+	// Ignore holds the glob patterns of the deprecated --ignore of diff and
+	// verify, which are excluded along with Exclude
+	Ignore []string
+}
@@ -0,0 +1,19 @@
+package flags
+
+import "github.com/jpwallace22/seed/internal/lint"
+
+type LintFlags struct {
+	Output string
+	// Rules come from the lint section of the config
+	Rules []lint.Rule
+	// Portable adds the checks of lint.WithPortable
+	Portable bool
+}
+
+// Options returns the linter options the flags ask for.
+func (f LintFlags) Options() []lint.Option {
+	if f.Portable {
+		return []lint.Option{lint.WithPortable()}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type LogLevel string
+
+var LogLevels = struct {
+	Debug LogLevel
+	Info  LogLevel
+	Warn  LogLevel
+	Error LogLevel
+}{
+	Debug: "debug",
+	Info:  "info",
+	Warn:  "warn",
+	Error: "error",
+}
+
+func (l LogLevel) String() string {
+	return string(l)
+}
+
+func (l *LogLevel) Set(value string) error {
+	switch LogLevel(value) {
+	case LogLevels.Debug, LogLevels.Info, LogLevels.Warn, LogLevels.Error:
+		*l = LogLevel(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid log level %q, must be one of: debug, info, warn, error", value)
+	}
+}
+
+func (l LogLevel) Type() string {
+	return "level"
+}
+
+// Level is the slog level, info when unset
+func (l LogLevel) Level() slog.Level {
+	switch l {
+	case LogLevels.Debug:
+		return slog.LevelDebug
+	case LogLevels.Warn:
+		return slog.LevelWarn
+	case LogLevels.Error:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type LogFormat string
+
+var LogFormats = struct {
+	Text LogFormat
+	JSON LogFormat
+}{
+	Text: "text",
+	JSON: "json",
+}
+
+func (f LogFormat) String() string {
+	return string(f)
+}
+
+func (f *LogFormat) Set(value string) error {
+	switch LogFormat(value) {
+	case LogFormats.Text, LogFormats.JSON:
+		*f = LogFormat(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q, must be one of: text, json", value)
+	}
+}
+
+func (f LogFormat) Type() string {
+	return "format"
+}
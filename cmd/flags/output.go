@@ -0,0 +1,8 @@
+package flags
+
+// OutputFlags are shared by the commands that print a tree or a report.
+type OutputFlags struct {
+	ToClipboard bool
+	// Indent is the indentation width of written JSON, YAML and markdown seeds
+	Indent int
+}
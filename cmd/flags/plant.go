@@ -0,0 +1,21 @@
+package flags
+
+type PlantFlags struct {
+	Rollback   bool
+	Jobs       int
+	Stream     bool
+	Report     string
+	ReportFile string
+	NoManifest bool
+	Conflict   Conflict
+	// Yes plants without the interactive preview
+	Yes bool
+	// NoHooks skips the hooks a seed declares, TrustHooks runs them without asking
+	NoHooks    bool
+	TrustHooks bool
+	// Git runs git in the planted root, GitMessage being the commit message
+	Git        GitActions
+	GitMessage string
+	// NoLint plants without checking the lint rules
+	NoLint bool
+}
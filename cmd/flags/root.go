@@ -1,38 +1,53 @@
 package flags
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+)
 
 type RootFlags struct {
-	FilePath      string
-	Format        Format
-	Silent        bool
-	FromClipboard bool
+	FilePath          string
+	Format            Format
+	Silent            bool
+	FromClipboard     bool
+	ClipboardProvider ClipboardProvider
+	Timeout           time.Duration
+	LogLevel          LogLevel
+	LogFormat         LogFormat
 }
 
 type Format string
 
 var Formats = struct {
-	Tree Format
-	JSON Format
-	YAML Format
+	Tree     Format
+	JSON     Format
+	YAML     Format
+	Markdown Format
+	Paths    Format
 }{
-	Tree: "tree",
-	JSON: "json",
-	YAML: "yaml",
+	Tree:     "tree",
+	JSON:     "json",
+	YAML:     "yaml",
+	Markdown: "markdown",
+	Paths:    "paths",
 }
 
 func (f Format) String() string {
 	return string(f)
 }
 
+// Set accepts any registered format. Whether it can be read or written is
+// checked when the parser or writer is built.
 func (f *Format) Set(value string) error {
-	switch Format(value) {
-	case Formats.Tree, Formats.JSON, Formats.YAML:
-		*f = Format(value)
-		return nil
-	default:
-		return fmt.Errorf("invalid format %q, must be one of: tree, json, yaml", value)
+	if _, ok := formats.Lookup(value); !ok {
+		return fmt.Errorf("invalid format %q, must be one of: %s, or a seed-parser-%s executable on $PATH",
+			value, strings.Join(formats.Names(), ", "), value)
 	}
+	*f = Format(value)
+	return nil
 }
 
 func (f Format) Type() string {
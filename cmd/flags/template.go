@@ -0,0 +1,9 @@
+package flags
+
+type TemplateFlags struct {
+	Dirs []string
+	Vars map[string]string
+	// TrustedDirs are the template directories of the user config and the
+	// default one, whose templates run their hooks without asking
+	TrustedDirs []string
+}
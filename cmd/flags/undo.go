@@ -0,0 +1,5 @@
+package flags
+
+type UndoFlags struct {
+	Force bool
+}
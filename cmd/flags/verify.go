@@ -0,0 +1,5 @@
+package flags
+
+type VerifyFlags struct {
+	Output string
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration seed runs with.",
+	Long: `Defaults are read from $XDG_CONFIG_HOME/seed/config.yaml, then from the nearest
+.seedrc.yaml in the current directory or its parents, then from SEED_*
+environment variables. Command line flags take precedence over all of them.`,
+	Args: cobra.NoArgs,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the merged configuration and where each value comes from.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewConfigRunner(cmd, ctx, seedConfig)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert [string]",
+	Short: "Convert a seed from one format to another.",
+	Long: `Convert parses a seed and writes it back out in another format without touching disk.
+Comments, modes and contents are kept wherever the target format can represent them.`,
+	Example: `  seed convert --from tree --to yaml -f project.seed
+  seed convert --from json --to tree --ascii -c`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.WithClipboardOutput(ctx, runner.NewConvertRunner(cmd, ctx))
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	convertCmd.Flags().Var(&flags.Root.Format, "from", inputFormatUsage("Format of the input, same as --format"))
+	convertCmd.Flags().VarP(&flags.Convert.To, "to", "t", outputFormatUsage("Format of the output"))
+	convertCmd.Flags().BoolVar(&flags.Convert.ASCII, "ascii", false, "Use ASCII connectors when writing the tree format.")
+	addOutputFlags(convertCmd)
+	addWriteFlags(convertCmd)
+	rootCmd.AddCommand(convertCmd)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <seed> [dir]",
+	Short: "Compare a seed against an existing directory.",
+	Long: `Diff reports paths that are missing from the directory, extra paths that the seed
+does not mention, and paths that exist as a file where the seed expects a directory
+or the other way around. It exits non-zero when the directory has drifted.`,
+	Example: `  seed diff layout.seed ./service
+  seed diff -F json -o json --ignore-extra layout.json`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.WithClipboardOutput(ctx, runner.NewDiffRunner(cmd, ctx))
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&flags.Diff.Output, "output", "o", "text", "Output format [text, json]")
+	diffCmd.Flags().BoolVar(&flags.Diff.IgnoreExtra, "ignore-extra", false, "Do not report paths that are not in the seed.")
+	addOutputFlags(diffCmd)
+	addIgnoreFlags(diffCmd, ".git")
+	addIgnoreAlias(diffCmd)
+	rootCmd.AddCommand(diffCmd)
+}
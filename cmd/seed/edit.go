@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit [file]",
+	Short: "Edit a seed in a terminal UI.",
+	Long: `Edit opens the seed in a full-screen editor where nodes can be added, renamed,
+moved, deleted and turned from files into directories and back, while a live
+diff shows what planting it would change in the --target directory. The seed
+is saved in the format of its extension, and W saves it under another name.
+
+A file that does not exist yet starts an empty seed. Without a file the seed
+is read from the clipboard or --file when given.`,
+	Example: `  seed edit layout.yaml
+  seed edit -c --target ./service`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewEditRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	editCmd.Flags().StringVarP(&flags.Edit.Target, "target", "t", ".", "Directory to compare the seed against.")
+	addWriteFlags(editCmd)
+	rootCmd.AddCommand(editCmd)
+}
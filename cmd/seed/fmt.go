@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [files...]",
+	Short: "Rewrite seed files in their canonical form.",
+	Long: `Fmt parses each seed file and renders it back with consistent connectors and indentation.
+The format is taken from the file extension, falling back to --format. Without
+files the seed from --file or --clipboard is formatted to stdout.`,
+	Example: `  seed fmt -w layout.seed
+  seed fmt --check --sort seeds/*.seed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.WithClipboardOutput(ctx, runner.NewFmtRunner(cmd, ctx))
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(&flags.Fmt.Check, "check", false, "List files that are not formatted and exit non-zero if there are any.")
+	fmtCmd.Flags().BoolVarP(&flags.Fmt.Write, "write", "w", false, "Write the result back to the source file instead of stdout.")
+	fmtCmd.Flags().BoolVar(&flags.Fmt.Sort, "sort", false, "Sort children with directories first, then alphabetically.")
+	addOutputFlags(fmtCmd)
+	addWriteFlags(fmtCmd)
+	rootCmd.AddCommand(fmtCmd)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var harvestCmd = &cobra.Command{
+	Use:   "harvest [dir]",
+	Short: "Print an existing directory as a seed.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.WithClipboardOutput(ctx, runner.NewHarvestRunner(cmd, ctx))
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	harvestCmd.Flags().BoolVarP(&flags.Harvest.All, "all", "a", false, "Include hidden files and directories.")
+	harvestCmd.Flags().StringVar(&flags.Harvest.GitRef, "git-ref", "", "Harvest the directory as it is in a git commit, branch or tag, without checking it out.")
+	addOutputFlags(harvestCmd)
+	addIgnoreFlags(harvestCmd)
+	rootCmd.AddCommand(harvestCmd)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [string]",
+	Short: "Check the names in a seed against the lint rules of the config.",
+	Long: `Lint applies the rules in the lint section of .seedrc.yaml to every node of
+the seed but the root. Rules select nodes by paths, depth and type, and check
+their names against a pattern, forbidden characters, a maximum length or a
+required sibling. Planting runs the same checks unless --no-lint is given.
+
+--portable, or portable: true in the lint section, also flags names that break
+when the planted tree is checked out on Windows or macOS: reserved names such as
+CON or NUL, trailing dots and spaces, the characters <>:"|?*\, paths over 260
+characters, and siblings that collide once case is ignored or Unicode is
+normalized, such as Readme.md and README.md.`,
+	Example: `  seed lint -f layout.tree
+  seed lint --portable -f layout.tree
+  seed lint -F yaml -f service.yaml -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewLintRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringVarP(&flags.Lint.Output, "output", "o", "text", "Output format [text, json]")
+	lintCmd.Flags().BoolVar(&flags.Lint.Portable, "portable", false, "Also flag names that break on Windows and macOS.")
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var plantCmd = &cobra.Command{
+	Use:   "plant [string]",
+	Short: "Grow a directory tree from a seed.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewPlantRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	addPlantFlags(plantCmd)
+	rootCmd.AddCommand(plantCmd)
+}
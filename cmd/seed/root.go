@@ -1,50 +1,203 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	cmdFlags "github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/config"
 	"github.com/jpwallace22/seed/internal/ctx"
 	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/jpwallace22/seed/pkg/clipboard"
+	"github.com/jpwallace22/seed/pkg/formats"
 	"github.com/spf13/cobra"
 )
 
 var flags = cmdFlags.Flags{
 	Root: cmdFlags.RootFlags{
-		Silent:        false,
-		FromClipboard: false,
-		FilePath:      "",
-		Format:        cmdFlags.Formats.Tree,
+		Silent:            false,
+		FromClipboard:     false,
+		FilePath:          "",
+		LogLevel:          cmdFlags.LogLevels.Info,
+		LogFormat:         cmdFlags.LogFormats.Text,
+		ClipboardProvider: clipboard.Auto,
+	},
+	Plant: cmdFlags.PlantFlags{
+		Conflict: cmdFlags.Conflicts.Overwrite,
+	},
+	Convert: cmdFlags.ConvertFlags{
+		To: cmdFlags.Formats.Tree,
 	},
 }
 
 func init() {
 	// Persistent Flags
 	rootCmd.PersistentFlags().BoolVarP(&flags.Root.Silent, "silent", "s", false, "If true, suppresses all non-essential console output.")
+	rootCmd.PersistentFlags().BoolVarP(&flags.Root.FromClipboard, "clipboard", "c", false, "Use tree structure from clipboard.")
+	rootCmd.PersistentFlags().Var(&flags.Root.ClipboardProvider, "clipboard-provider", "Clipboard to use ["+strings.Join(clipboard.Names(), ", ")+"]")
+	rootCmd.PersistentFlags().StringVarP(&flags.Root.FilePath, "file", "f", "", "Use tree structure from a file.")
+	rootCmd.PersistentFlags().VarP(&flags.Root.Format, "format", "F", inputFormatUsage("Format of the input"))
+	rootCmd.PersistentFlags().DurationVar(&flags.Root.Timeout, "timeout", 0, "Give up after this long, e.g. 30s. No limit by default.")
+	rootCmd.PersistentFlags().Var(&flags.Root.LogLevel, "log-level", "Minimum level to log [debug, info, warn, error]")
+	rootCmd.PersistentFlags().Var(&flags.Root.LogFormat, "log-format", "Log format on stderr [text, json]")
 
-	// Command Flags
-	rootCmd.Flags().BoolVarP(&flags.Root.FromClipboard, "clipboard", "c", false, "Use tree structure from clipboard.")
-	rootCmd.Flags().StringVarP(&flags.Root.FilePath, "file", "f", "", "Use tree structure from a file.")
-	rootCmd.Flags().VarP(&flags.Root.Format, "format", "F", "Format of the input [tree, json, yaml]")
+	// Flags
+	addPlantFlags(rootCmd)
 }
 
+// addPlantFlags registers the flags of every command that plants a tree
+func addPlantFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&flags.Plant.Rollback, "rollback", false, "Remove everything planted so far when planting fails or is interrupted.")
+	cmd.Flags().IntVarP(&flags.Plant.Jobs, "jobs", "j", 1, "Number of paths to plant in parallel.")
+	cmd.Flags().BoolVar(&flags.Plant.Stream, "stream", false, "Plant a --file while it is read, in bounded memory. Parse errors can leave a partial tree.")
+	cmd.Flags().StringVar(&flags.Plant.Report, "report", "", "Write a report of every planted path [json, yaml].")
+	cmd.Flags().StringVar(&flags.Plant.ReportFile, "report-file", "", "Write the report to a file instead of stdout.")
+	cmd.Flags().Var(&flags.Plant.Conflict, "conflict", "What to do with files that already exist [overwrite, skip, error]")
+	cmd.Flags().BoolVarP(&flags.Plant.Yes, "yes", "y", false, "Plant without previewing the tree and asking for confirmation.")
+	cmd.Flags().BoolVar(&flags.Plant.NoHooks, "no-hooks", false, "Do not run the hooks the seed declares.")
+	cmd.Flags().BoolVar(&flags.Plant.TrustHooks, "trust-hooks", false, "Run the hooks the seed declares without asking.")
+	cmd.Flags().Var(&flags.Plant.Git, "git", "Run git in the planted root, empty directories getting a .gitkeep [init, add, commit]")
+	cmd.Flags().StringVarP(&flags.Plant.GitMessage, "git-message", "m", "", "Message of the --git commit. (default \"Plant <root>\")")
+	cmd.Flags().BoolVar(&flags.Plant.NoLint, "no-lint", false, "Plant without checking the lint rules of the config.")
+	cmd.Flags().BoolVar(&flags.Plant.NoManifest, "no-manifest", false, "Do not write the .seed manifest that seed undo reverts.")
+}
+
+// addOutputFlags registers the flags of every command that prints a tree or a report
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&flags.Output.ToClipboard, "to-clipboard", false, "Copy the output to the clipboard instead of printing it.")
+}
+
+// addIgnoreFlags registers the flags of every command that reads an existing
+// directory, exclude being the default of --exclude
+func addIgnoreFlags(cmd *cobra.Command, exclude ...string) {
+	cmd.Flags().BoolVar(&flags.Ignore.NoIgnore, "no-ignore", false, "Do not honour .gitignore, .seedignore and the git excludes files.")
+	cmd.Flags().StringSliceVar(&flags.Ignore.Include, "include", nil, "Only read paths matching these gitignore style patterns.")
+	cmd.Flags().StringSliceVar(&flags.Ignore.Exclude, "exclude", nil, "Leave out paths matching these gitignore style patterns.")
+	if len(exclude) == 0 {
+		return
+	}
+
+	// flags is shared by every command, so the default is only stored once the
+	// command runs rather than leaking into the others
+	cmd.Flags().Lookup("exclude").DefValue = "[" + strings.Join(exclude, ",") + "]"
+	cmd.PreRun = func(cmd *cobra.Command, _ []string) {
+		if !cmd.Flags().Changed("exclude") {
+			flags.Ignore.Exclude = exclude
+		}
+	}
+}
+
+// addIgnoreAlias keeps the --ignore of diff and verify working, which --exclude replaced
+func addIgnoreAlias(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&flags.Ignore.Ignore, "ignore", nil, "Patterns to leave out, the same as --exclude.")
+	_ = cmd.Flags().MarkDeprecated("ignore", "use --exclude instead")
+}
+
+// addWriteFlags registers the flags of every command that writes a seed
+func addWriteFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&flags.Output.Indent, "indent", 2, "Indentation width of JSON, YAML and markdown output.")
+}
+
+// cancelTimeout releases the --timeout context once the command has finished
+var cancelTimeout context.CancelFunc = func() {}
+
 var rootCmd = &cobra.Command{
 	Version: "0.1.1",
 	Use:     "seed [string]",
 	Short:   "Plant the seeds of your directory tree 🌱.",
-	Long:    "Seed is a CLI tool that helps you grow directory structures from a tree representation provided via string or clipboard.",
-	Args:    cobra.MaximumNArgs(1),
+	Long: `Seed is a CLI tool that helps you grow directory structures from a tree representation provided via string or clipboard.
+
+Running seed without a subcommand is the same as running seed plant.`,
+	Args: cobra.MaximumNArgs(1),
+	// Execute prints the error itself, and usage is noise once the command has run
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(cmd); err != nil {
+			return err
+		}
+		if flags.Root.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), flags.Root.Timeout)
+			cmd.SetContext(ctx)
+			cancelTimeout = cancel
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := ctx.New(cmd, flags)
-		runner := runner.NewRootRunner(cmd, ctx)
+		runner := runner.NewPlantRunner(cmd, ctx)
 		return runner.Run(args)
 	},
 }
 
+// seedConfig is the merged configuration of the running command, loaded
+// before it runs
+var seedConfig = config.Default()
+
+// loadConfig fills the flags that were not given on the command line from the
+// config files and SEED_* environment variables
+func loadConfig(cmd *cobra.Command) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	loaded, err := config.Load(cwd)
+	if err != nil {
+		return err
+	}
+	if err := loaded.Apply(cmd.Flags(), &flags); err != nil {
+		return err
+	}
+	seedConfig = loaded
+	return nil
+}
+
+// inputFormatUsage lists the registered input formats, so formats added by
+// other packages show up in --help without touching the flags
+func inputFormatUsage(prefix string) string {
+	names := formats.Names(formats.Format.CanParse)
+	return fmt.Sprintf("%s [%s, or any seed-parser-<name> on $PATH], detected from the content when omitted.", prefix, strings.Join(names, ", "))
+}
+
+func outputFormatUsage(prefix string) string {
+	names := formats.Names(formats.Format.CanWrite)
+	return fmt.Sprintf("%s [%s]", prefix, strings.Join(names, ", "))
+}
+
+// Execute runs seed until it finishes or is interrupted. The first Ctrl-C
+// cancels the command context so planting stops at a safe point, a second one
+// exits immediately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalCtx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(signalCtx)
+	cancelTimeout()
+	stop()
+
+	if err == nil {
+		return
+	}
+
+	// errors go through the logger so --log-format json applies to them too
+	logger := ctx.NewLogger(os.Stderr, flags.Root)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Error("%v: timed out after %s", err, flags.Root.Timeout)
+		os.Exit(1)
+	case errors.Is(err, context.Canceled):
+		logger.Error("%v: interrupted", err)
+		os.Exit(130)
+	default:
+		logger.Error("%v", err)
 		os.Exit(1)
 	}
 }
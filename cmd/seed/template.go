@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template [name]",
+	Short: "List templates, or plant one with --var substitutions.",
+	Long: `Templates are seeds stored in a template directory that may reference
+variables as {{.name}}. Without a name the available templates are listed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewTemplateRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	templateCmd.Flags().StringSliceVarP(&flags.Template.Dirs, "dir", "d", nil, "Directories to load templates from, in order (default is the user config directory).")
+	templateCmd.Flags().StringToStringVar(&flags.Template.Vars, "var", nil, "Template variables as key=value.")
+	addPlantFlags(templateCmd)
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [manifest]",
+	Short: "Revert a planting using the manifest it wrote.",
+	Long: `Every planting writes a manifest to .seed/ listing the paths it created and
+backups of the files it overwrote. Undo removes the created paths in reverse
+order and restores the backups. Without a manifest the latest one in the
+current directory is used.
+
+Files modified since they were planted are never removed unless --force is given.`,
+	Example: `  seed undo
+  seed undo .seed/manifest-20261019T101500.000000000.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewUndoRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	undoCmd.Flags().BoolVar(&flags.Undo.Force, "force", false, "Remove and restore files even when they were modified since planting.")
+	rootCmd.AddCommand(undoCmd)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [string]",
+	Short: "Parse a seed without planting it.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewValidateRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
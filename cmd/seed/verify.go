@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <seed> [dir]",
+	Short: "Check a directory against the rules in a seed.",
+	Long: `Verify treats every node of the seed as a rule and reports whether each one passes.
+
+  name       required, must exist under every matching parent
+  name?      optional, only checked for the right type when present
+  !name      forbidden, must not exist
+  {a,b}      at least one of the alternatives must exist
+  *.go       glob patterns match any number of entries, e.g. internal/*/*.go`,
+	Example: `  seed verify monorepo.seed
+  seed verify -o json service.seed ./services/billing`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := ctx.New(cmd, flags)
+		runner := runner.NewVerifyRunner(cmd, ctx)
+		return runner.Run(args)
+	},
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&flags.Verify.Output, "output", "o", "text", "Output format [text, json]")
+	addIgnoreFlags(verifyCmd, ".git")
+	addIgnoreAlias(verifyCmd)
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,363 @@
+// Package config loads seed defaults from config files and SEED_* environment
+// variables. Later sources win: built-in defaults, the user config, the nearest
+// project .seedrc.yaml, the environment, and finally command line flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/lint"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFile is looked up in the working directory and each of its parents.
+const ProjectFile = ".seedrc.yaml"
+
+// Sources that are not a file
+const (
+	SourceDefault = "default"
+	SourceFlag    = "flag"
+)
+
+// Setting is a value along with where it came from.
+type Setting[T any] struct {
+	Value  T
+	Source string
+}
+
+// Config is the merged configuration.
+type Config struct {
+	// Format is the input format, "auto" to detect it from the content
+	Format Setting[string]
+	// Indent is the indentation width of written JSON, YAML and markdown seeds
+	Indent Setting[int]
+	// Conflict is what planting does with existing files
+	Conflict     Setting[string]
+	TemplateDirs Setting[[]string]
+	// TemplateVars are merged key by key across sources
+	TemplateVars map[string]Setting[string]
+	// LintRules replace each other as a whole, like the other settings
+	LintRules Setting[[]lint.Rule]
+	// LintPortable adds the checks for names that break on Windows and macOS
+	LintPortable Setting[bool]
+	// Files lists the config files that were loaded, lowest precedence first
+	Files []string
+}
+
+// file is the layout of .seedrc.yaml and config.yaml
+type file struct {
+	Format   *string `yaml:"format"`
+	Indent   *int    `yaml:"indent"`
+	Conflict *string `yaml:"conflict"`
+	Template struct {
+		Dirs []string          `yaml:"dirs"`
+		Vars map[string]string `yaml:"vars"`
+	} `yaml:"template"`
+	Lint struct {
+		Rules    []lint.Rule `yaml:"rules"`
+		Portable *bool       `yaml:"portable"`
+	} `yaml:"lint"`
+}
+
+// Default is the configuration without any file, variable or flag.
+func Default() *Config {
+	return &Config{
+		Format:       Setting[string]{Value: "auto", Source: SourceDefault},
+		Indent:       Setting[int]{Value: 2, Source: SourceDefault},
+		Conflict:     Setting[string]{Value: string(flags.Conflicts.Overwrite), Source: SourceDefault},
+		TemplateDirs: Setting[[]string]{Value: []string{DefaultTemplateDir()}, Source: SourceDefault},
+		TemplateVars: map[string]Setting[string]{},
+		LintRules:    Setting[[]lint.Rule]{Source: SourceDefault},
+		LintPortable: Setting[bool]{Source: SourceDefault},
+	}
+}
+
+// DefaultTemplateDir is where templates live when no directory is configured.
+func DefaultTemplateDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "seed", "templates")
+}
+
+// UserFile is the user config, $XDG_CONFIG_HOME/seed/config.yaml.
+func UserFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		var err error
+		if dir, err = os.UserConfigDir(); err != nil {
+			return ""
+		}
+	}
+	return filepath.Join(dir, "seed", "config.yaml")
+}
+
+// TrustFile lists the digests of the seeds whose hooks were always trusted.
+func TrustFile() string {
+	user := UserFile()
+	if user == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(user), "trusted")
+}
+
+// FindProjectFile walks up from dir to the nearest .seedrc.yaml. It returns
+// an empty path when there is none.
+func FindProjectFile(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load merges the defaults, the user config, the project config found from
+// dir and the environment. Missing files are fine, invalid ones are not.
+func Load(dir string) (*Config, error) {
+	c := Default()
+
+	for _, path := range []string{UserFile(), FindProjectFile(dir)} {
+		if path == "" {
+			continue
+		}
+		if err := c.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.loadEnv(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read config: %w", err)
+	}
+
+	var f file
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	c.Files = append(c.Files, path)
+	if f.Format != nil {
+		c.Format = Setting[string]{Value: *f.Format, Source: path}
+	}
+	if f.Indent != nil {
+		c.Indent = Setting[int]{Value: *f.Indent, Source: path}
+	}
+	if f.Conflict != nil {
+		c.Conflict = Setting[string]{Value: *f.Conflict, Source: path}
+	}
+	if len(f.Template.Dirs) > 0 {
+		// relative directories are relative to the config file
+		dirs := make([]string, 0, len(f.Template.Dirs))
+		for _, d := range f.Template.Dirs {
+			dirs = append(dirs, resolveDir(filepath.Dir(path), d))
+		}
+		c.TemplateDirs = Setting[[]string]{Value: dirs, Source: path}
+	}
+	for k, v := range f.Template.Vars {
+		c.TemplateVars[k] = Setting[string]{Value: v, Source: path}
+	}
+	if len(f.Lint.Rules) > 0 {
+		if _, err := lint.New(f.Lint.Rules); err != nil {
+			return fmt.Errorf("invalid config %s: %w", path, err)
+		}
+		c.LintRules = Setting[[]lint.Rule]{Value: f.Lint.Rules, Source: path}
+	}
+	if f.Lint.Portable != nil {
+		c.LintPortable = Setting[bool]{Value: *f.Lint.Portable, Source: path}
+	}
+	return nil
+}
+
+// Environment variables read by loadEnv
+const (
+	EnvFormat       = "SEED_FORMAT"
+	EnvIndent       = "SEED_INDENT"
+	EnvConflict     = "SEED_CONFLICT"
+	EnvTemplateDirs = "SEED_TEMPLATE_DIRS"
+	EnvTemplateVars = "SEED_TEMPLATE_VARS"
+)
+
+func (c *Config) loadEnv() error {
+	if v := os.Getenv(EnvFormat); v != "" {
+		c.Format = Setting[string]{Value: v, Source: EnvFormat}
+	}
+	if v := os.Getenv(EnvIndent); v != "" {
+		indent, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: invalid indent %q", EnvIndent, v)
+		}
+		c.Indent = Setting[int]{Value: indent, Source: EnvIndent}
+	}
+	if v := os.Getenv(EnvConflict); v != "" {
+		c.Conflict = Setting[string]{Value: v, Source: EnvConflict}
+	}
+	if v := os.Getenv(EnvTemplateDirs); v != "" {
+		c.TemplateDirs = Setting[[]string]{Value: filepath.SplitList(v), Source: EnvTemplateDirs}
+	}
+	if v := os.Getenv(EnvTemplateVars); v != "" {
+		for _, pair := range strings.Split(v, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("%s: %q is not key=value", EnvTemplateVars, pair)
+			}
+			c.TemplateVars[key] = Setting[string]{Value: value, Source: EnvTemplateVars}
+		}
+	}
+	return nil
+}
+
+// changed returns the first of names given on the command line
+func changed(fs *pflag.FlagSet, names ...string) (string, bool) {
+	for _, name := range names {
+		if fs.Changed(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Apply fills f with the config, leaving the flags given on the command line
+// in fs alone. Those are recorded in c instead, so c describes what the
+// command actually runs with.
+func (c *Config) Apply(fs *pflag.FlagSet, f *flags.Flags) error {
+	flagSource := func(name string) string {
+		return SourceFlag + " --" + name
+	}
+
+	// convert reads the input format from --from
+	if name, ok := changed(fs, "format", "from"); ok {
+		c.Format = Setting[string]{Value: f.Root.Format.String(), Source: flagSource(name)}
+	} else if c.Format.Value == "auto" {
+		f.Root.Format = ""
+	} else if err := f.Root.Format.Set(c.Format.Value); err != nil {
+		return fmt.Errorf("%s: %w", c.Format.Source, err)
+	}
+
+	if fs.Changed("indent") {
+		c.Indent = Setting[int]{Value: f.Output.Indent, Source: flagSource("indent")}
+	} else {
+		f.Output.Indent = c.Indent.Value
+	}
+	if c.Indent.Value < 1 {
+		return fmt.Errorf("%s: invalid indent %d, must be at least 1", c.Indent.Source, c.Indent.Value)
+	}
+
+	if fs.Changed("conflict") {
+		c.Conflict = Setting[string]{Value: f.Plant.Conflict.String(), Source: flagSource("conflict")}
+	} else if err := f.Plant.Conflict.Set(c.Conflict.Value); err != nil {
+		return fmt.Errorf("%s: %w", c.Conflict.Source, err)
+	}
+
+	// a project config comes with the repository it is in, so the hooks of its
+	// templates are no more trusted than those of any other seed
+	f.Template.TrustedDirs = nil
+	if dir := DefaultTemplateDir(); dir != "" {
+		f.Template.TrustedDirs = append(f.Template.TrustedDirs, dir)
+	}
+	if user := UserFile(); user != "" && c.TemplateDirs.Source == user {
+		f.Template.TrustedDirs = append(f.Template.TrustedDirs, c.TemplateDirs.Value...)
+	}
+
+	if fs.Changed("dir") {
+		c.TemplateDirs = Setting[[]string]{Value: f.Template.Dirs, Source: flagSource("dir")}
+	} else {
+		f.Template.Dirs = c.TemplateDirs.Value
+	}
+
+	vars := make(map[string]string, len(c.TemplateVars))
+	for k, v := range c.TemplateVars {
+		vars[k] = v.Value
+	}
+	for k, v := range f.Template.Vars {
+		vars[k] = v
+		c.TemplateVars[k] = Setting[string]{Value: v, Source: flagSource("var")}
+	}
+	f.Template.Vars = vars
+	f.Lint.Rules = c.LintRules.Value
+
+	if fs.Changed("portable") {
+		c.LintPortable = Setting[bool]{Value: f.Lint.Portable, Source: flagSource("portable")}
+	} else {
+		f.Lint.Portable = c.LintPortable.Value
+	}
+	return nil
+}
+
+// Entry is a single setting in display form.
+type Entry struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// Entries lists every setting in a stable order, template variables last.
+func (c *Config) Entries() []Entry {
+	entries := []Entry{
+		{Key: "format", Value: c.Format.Value, Source: c.Format.Source},
+		{Key: "indent", Value: strconv.Itoa(c.Indent.Value), Source: c.Indent.Source},
+		{Key: "conflict", Value: c.Conflict.Value, Source: c.Conflict.Source},
+		{Key: "template.dirs", Value: "[" + strings.Join(c.TemplateDirs.Value, ", ") + "]", Source: c.TemplateDirs.Source},
+	}
+	rules := make([]string, 0, len(c.LintRules.Value))
+	for _, r := range c.LintRules.Value {
+		rules = append(rules, r.Name)
+	}
+	entries = append(entries, Entry{Key: "lint.rules", Value: "[" + strings.Join(rules, ", ") + "]", Source: c.LintRules.Source})
+	entries = append(entries, Entry{Key: "lint.portable", Value: strconv.FormatBool(c.LintPortable.Value), Source: c.LintPortable.Source})
+
+	keys := make([]string, 0, len(c.TemplateVars))
+	for k := range c.TemplateVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entries = append(entries, Entry{Key: "template.vars." + k, Value: c.TemplateVars[k].Value, Source: c.TemplateVars[k].Source})
+	}
+	return entries
+}
+
+// resolveDir expands ~ and makes dir absolute relative to base
+func resolveDir(base, dir string) string {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(base, dir)
+}
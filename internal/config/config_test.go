@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/lint"
+	// registers the formats that --format is validated against
+	_ "github.com/jpwallace22/seed/internal/parser"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate keeps the user config and SEED_* variables of the machine out of a test
+func isolate(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, env := range []string{EnvFormat, EnvIndent, EnvConflict, EnvTemplateDirs, EnvTemplateVars} {
+		t.Setenv(env, "")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	isolate(t)
+	user := UserFile()
+	writeFile(t, user, "format: json\nindent: 3\ntemplate:\n  vars:\n    name: user\n    owner: me\n")
+
+	project := t.TempDir()
+	writeFile(t, filepath.Join(project, ProjectFile), "indent: 4\ntemplate:\n  dirs: [templates]\n  vars:\n    name: project\n")
+	cwd := filepath.Join(project, "a", "b")
+	require.NoError(t, os.MkdirAll(cwd, 0755))
+
+	t.Setenv(EnvConflict, "skip")
+
+	c, err := Load(cwd)
+	require.NoError(t, err)
+
+	projectFile := filepath.Join(project, ProjectFile)
+	assert.Equal(t, []string{user, projectFile}, c.Files)
+	assert.Equal(t, Setting[string]{Value: "json", Source: user}, c.Format)
+	assert.Equal(t, Setting[int]{Value: 4, Source: projectFile}, c.Indent)
+	assert.Equal(t, Setting[string]{Value: "skip", Source: EnvConflict}, c.Conflict)
+	assert.Equal(t, []string{filepath.Join(project, "templates")}, c.TemplateDirs.Value)
+	assert.Equal(t, Setting[string]{Value: "project", Source: projectFile}, c.TemplateVars["name"])
+	assert.Equal(t, Setting[string]{Value: "me", Source: user}, c.TemplateVars["owner"])
+	assert.Empty(t, c.LintRules.Value)
+
+	assert.Equal(t, Setting[bool]{Source: SourceDefault}, c.LintPortable)
+
+	writeFile(t, projectFile, "lint:\n  portable: true\n  rules:\n    - name: short\n      max-length: 30\n")
+	c, err = Load(cwd)
+	require.NoError(t, err)
+	assert.Equal(t, []lint.Rule{{Name: "short", MaxLength: 30}}, c.LintRules.Value)
+	assert.Equal(t, Setting[bool]{Value: true, Source: projectFile}, c.LintPortable)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	isolate(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ProjectFile), "indnet: 4\n")
+
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "invalid config")
+
+	writeFile(t, filepath.Join(dir, ProjectFile), "lint:\n  rules:\n    - name: dirs\n      pattern: '[a-z'\n")
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, `lint rule "dirs": invalid pattern`)
+
+	isolate(t)
+	t.Setenv(EnvTemplateVars, "name")
+	_, err = Load(t.TempDir())
+	assert.ErrorContains(t, err, "is not key=value")
+}
+
+func TestApply(t *testing.T) {
+	isolate(t)
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	f := flags.Flags{}
+	fs.Var(&f.Root.Format, "format", "")
+	fs.IntVar(&f.Output.Indent, "indent", 2, "")
+	fs.Var(&f.Plant.Conflict, "conflict", "")
+	fs.StringToStringVar(&f.Template.Vars, "var", nil, "")
+	fs.BoolVar(&f.Lint.Portable, "portable", false, "")
+	require.NoError(t, fs.Parse([]string{"--conflict", "error", "--var", "name=flag"}))
+
+	c := Default()
+	c.Format = Setting[string]{Value: "yaml", Source: "SEED_FORMAT"}
+	c.Indent = Setting[int]{Value: 4, Source: "config.yaml"}
+	c.Conflict = Setting[string]{Value: "skip", Source: "config.yaml"}
+	c.TemplateVars["name"] = Setting[string]{Value: "config", Source: "config.yaml"}
+	c.TemplateVars["owner"] = Setting[string]{Value: "me", Source: "config.yaml"}
+	c.LintPortable = Setting[bool]{Value: true, Source: "config.yaml"}
+
+	require.NoError(t, c.Apply(fs, &f))
+	assert.Equal(t, flags.Formats.YAML, f.Root.Format)
+	assert.Equal(t, 4, f.Output.Indent)
+	assert.Equal(t, flags.Conflicts.Error, f.Plant.Conflict)
+	assert.Equal(t, "flag --conflict", c.Conflict.Source)
+	assert.Equal(t, map[string]string{"name": "flag", "owner": "me"}, f.Template.Vars)
+	assert.Equal(t, "flag --var", c.TemplateVars["name"].Source)
+	assert.True(t, f.Lint.Portable)
+
+	assert.Equal(t, []string{DefaultTemplateDir()}, f.Template.TrustedDirs)
+
+	c = Default()
+	c.TemplateDirs = Setting[[]string]{Value: []string{"/home/me/tpl"}, Source: UserFile()}
+	require.NoError(t, c.Apply(fs, &f))
+	assert.Equal(t, []string{DefaultTemplateDir(), "/home/me/tpl"}, f.Template.TrustedDirs)
+
+	c = Default()
+	c.TemplateDirs = Setting[[]string]{Value: []string{"/repo/tpl"}, Source: "/repo/.seedrc.yaml"}
+	require.NoError(t, c.Apply(fs, &f))
+	assert.Equal(t, []string{DefaultTemplateDir()}, f.Template.TrustedDirs, "project templates are not trusted")
+
+	c = Default()
+	c.Format = Setting[string]{Value: "xml", Source: "SEED_FORMAT"}
+	assert.ErrorContains(t, c.Apply(fs, &flags.Flags{}), "SEED_FORMAT: ")
+}
+
+func TestApplyFrom(t *testing.T) {
+	isolate(t)
+	fs := pflag.NewFlagSet("convert", pflag.ContinueOnError)
+	f := flags.Flags{}
+	fs.Var(&f.Root.Format, "from", "")
+	require.NoError(t, fs.Parse([]string{"--from", "tree"}))
+
+	c := Default()
+	require.NoError(t, c.Apply(fs, &f))
+	assert.Equal(t, flags.Formats.Tree, f.Root.Format)
+	assert.Equal(t, Setting[string]{Value: "tree", Source: "flag --from"}, c.Format)
+}
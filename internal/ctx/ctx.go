@@ -1,23 +1,54 @@
 package ctx
 
 import (
-	"os"
+	"context"
+	"io"
+	"log/slog"
 
 	"github.com/jpwallace22/seed/cmd/flags"
 	"github.com/jpwallace22/seed/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
+// SeedContext is the state shared by every subcommand runner.
 type SeedContext struct {
 	Logger logger.Logger
 	Cobra  *cobra.Command
 	Flags  flags.Flags
+	// Out receives command output such as harvested or converted trees,
+	// kept apart from the Logger so it can be piped.
+	Out io.Writer
 }
 
 func New(cobra *cobra.Command, flags flags.Flags) *SeedContext {
 	return &SeedContext{
 		Cobra:  cobra,
-		Logger: logger.NewLogger(os.Stdin, os.Stderr, flags.Root.Silent),
+		Logger: NewLogger(cobra.ErrOrStderr(), flags.Root),
 		Flags:  flags,
+		Out:    cobra.OutOrStdout(),
 	}
 }
+
+// NewLogger writes logs to w, which is stderr so logs never mix with command
+// output. --silent only lets errors through.
+func NewLogger(w io.Writer, flags flags.RootFlags) logger.Logger {
+	level := flags.LogLevel.Level()
+	if flags.Silent {
+		level = slog.LevelError
+	}
+
+	return logger.New(w, logger.Options{
+		Level:  level,
+		Format: logger.Format(flags.LogFormat),
+		Color:  logger.ColorEnabled(w),
+	})
+}
+
+// Context is the cobra command context, or a background one when the command
+// was never executed, as in tests.
+func (c *SeedContext) Context() context.Context {
+	if c.Cobra != nil && c.Cobra.Context() != nil {
+		return c.Cobra.Context()
+	}
+	return context.Background()
+}
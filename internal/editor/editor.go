@@ -0,0 +1,538 @@
+// Package editor is the terminal UI behind seed edit. The Editor itself is a
+// plain state machine fed with key names and rendered to lines, so it can be
+// driven without a terminal; Run connects it to one.
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Key names handed to HandleKey besides printable characters
+const (
+	KeyUp        = "up"
+	KeyDown      = "down"
+	KeyLeft      = "left"
+	KeyRight     = "right"
+	KeyHome      = "home"
+	KeyEnd       = "end"
+	KeyEnter     = "enter"
+	KeyEscape    = "esc"
+	KeyBackspace = "backspace"
+	KeyDelete    = "delete"
+	KeyCtrlC     = "ctrl+c"
+)
+
+// historyLimit bounds how many edits can be undone
+const historyLimit = 100
+
+const help = "a/A add  r rename  d delete  t file/dir  J/K/H/L move  u undo  w/W save  F format  q quit"
+
+// Editor edits a tree in memory. Every edit can be undone and the tree is only
+// written when saved.
+type Editor struct {
+	root   *tree.Node
+	path   string
+	format seed.Format
+	indent int
+	dest   string
+	color  bool
+
+	cursor    int
+	offset    int
+	collapsed map[*tree.Node]bool
+	history   []*tree.Node
+	dirty     bool
+	prompt    *prompt
+	message   string
+	// quitting is set by a first q with unsaved changes
+	quitting bool
+	done     bool
+
+	// harvested caches the destination by path, it is only read once
+	harvested map[string]*tree.Node
+}
+
+type Option func(*Editor)
+
+// WithFormat sets the format the seed is saved in, the tree format by default.
+func WithFormat(format seed.Format) Option {
+	return func(e *Editor) {
+		e.format = format
+	}
+}
+
+// WithIndent sets the indentation width of saved JSON, YAML and markdown seeds.
+func WithIndent(width int) Option {
+	return func(e *Editor) {
+		e.indent = width
+	}
+}
+
+// WithDest sets the directory the tree is compared against, the working
+// directory by default.
+func WithDest(dir string) Option {
+	return func(e *Editor) {
+		e.dest = dir
+	}
+}
+
+// WithColor highlights the selection and the status of each node with ANSI colours.
+func WithColor(color bool) Option {
+	return func(e *Editor) {
+		e.color = color
+	}
+}
+
+// New edits root, which is saved to path. An empty path asks for one on save.
+func New(root *tree.Node, path string, opts ...Option) *Editor {
+	e := &Editor{
+		root:      root,
+		path:      path,
+		format:    seed.FormatTree,
+		dest:      ".",
+		collapsed: make(map[*tree.Node]bool),
+		harvested: make(map[string]*tree.Node),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Root is the tree being edited.
+func (e *Editor) Root() *tree.Node {
+	return e.root
+}
+
+// Done reports whether the user quit.
+func (e *Editor) Done() bool {
+	return e.done
+}
+
+// prompt reads a line of text at the bottom of the screen
+type prompt struct {
+	label  string
+	text   string
+	submit func(text string) error
+}
+
+func (e *Editor) ask(label, text string, submit func(string) error) {
+	e.prompt = &prompt{label: label, text: text, submit: submit}
+}
+
+func (e *Editor) handlePrompt(key string) {
+	p := e.prompt
+	switch key {
+	case KeyEnter:
+		e.prompt = nil
+		if err := p.submit(strings.TrimSpace(p.text)); err != nil {
+			e.message = "Error: " + err.Error()
+		}
+	case KeyEscape, KeyCtrlC:
+		e.prompt = nil
+	case KeyBackspace:
+		if runes := []rune(p.text); len(runes) > 0 {
+			p.text = string(runes[:len(runes)-1])
+		}
+	default:
+		if len([]rune(key)) == 1 {
+			p.text += key
+		}
+	}
+}
+
+// HandleKey applies a key press, either a single character or one of the Key names.
+func (e *Editor) HandleKey(key string) {
+	if e.prompt != nil {
+		e.handlePrompt(key)
+		return
+	}
+
+	e.message = ""
+	if key != "q" && key != KeyCtrlC {
+		e.quitting = false
+	}
+
+	rows := e.rows()
+	current := rows[e.cursor]
+	switch key {
+	case KeyUp, "k":
+		e.cursor--
+	case KeyDown, "j":
+		e.cursor++
+	case KeyHome, "g":
+		e.cursor = 0
+	case KeyEnd, "G":
+		e.cursor = len(rows) - 1
+	case KeyEnter, " ":
+		if !current.node.IsFile && len(current.node.Children) > 0 {
+			e.collapsed[current.node] = !e.collapsed[current.node]
+		}
+	case KeyLeft, "h":
+		if !current.node.IsFile && len(current.node.Children) > 0 && !e.collapsed[current.node] {
+			e.collapsed[current.node] = true
+		} else if current.parent != nil {
+			e.selectNode(current.parent)
+		}
+	case KeyRight, "l":
+		delete(e.collapsed, current.node)
+	case "a":
+		e.startAdd(current, true)
+	case "A":
+		e.startAdd(current, false)
+	case "r":
+		e.startRename(current)
+	case "d", KeyDelete:
+		e.remove(current)
+	case "t":
+		e.toggle(current)
+	case "K":
+		e.moveSibling(current, -1)
+	case "J":
+		e.moveSibling(current, 1)
+	case "H":
+		e.outdent(current)
+	case "L":
+		e.indentNode(current)
+	case "u":
+		e.undo()
+	case "w":
+		e.startSave(false)
+	case "W":
+		e.startSave(true)
+	case "F":
+		e.cycleFormat()
+	case "q", KeyCtrlC:
+		if e.dirty && !e.quitting {
+			e.quitting = true
+			e.message = "Unsaved changes, press q again to quit without saving"
+			return
+		}
+		e.done = true
+	}
+	e.clamp()
+}
+
+// row is a visible line of the tree
+type row struct {
+	node   *tree.Node
+	parent *tree.Node
+	prefix string
+}
+
+// rows flattens the expanded part of the tree in seed order
+func (e *Editor) rows() []row {
+	rows := []row{{node: e.root}}
+	var walk func(parent *tree.Node, prefix string)
+	walk = func(parent *tree.Node, prefix string) {
+		if e.collapsed[parent] {
+			return
+		}
+		for i, child := range parent.Children {
+			connector, indent := "├── ", "│   "
+			if i == len(parent.Children)-1 {
+				connector, indent = "└── ", "    "
+			}
+			rows = append(rows, row{node: child, parent: parent, prefix: prefix + connector})
+			walk(child, prefix+indent)
+		}
+	}
+	walk(e.root, "")
+	return rows
+}
+
+func (e *Editor) clamp() {
+	last := len(e.rows()) - 1
+	if e.cursor > last {
+		e.cursor = last
+	}
+	if e.cursor < 0 {
+		e.cursor = 0
+	}
+}
+
+// selectNode moves the cursor to node, expanding its parents
+func (e *Editor) selectNode(node *tree.Node) {
+	for parent := parentOf(e.root, node); parent != nil; parent = parentOf(e.root, parent) {
+		delete(e.collapsed, parent)
+	}
+	for i, r := range e.rows() {
+		if r.node == node {
+			e.cursor = i
+			return
+		}
+	}
+}
+
+// snapshot records the tree before an edit so it can be undone
+func (e *Editor) snapshot() {
+	e.history = append(e.history, e.root.Clone())
+	if len(e.history) > historyLimit {
+		e.history = e.history[1:]
+	}
+	e.dirty = true
+}
+
+func (e *Editor) undo() {
+	if len(e.history) == 0 {
+		e.message = "Nothing to undo"
+		return
+	}
+	e.root = e.history[len(e.history)-1]
+	e.history = e.history[:len(e.history)-1]
+	e.collapsed = make(map[*tree.Node]bool)
+	e.dirty = true
+	e.message = "Undone"
+}
+
+func (e *Editor) startAdd(current row, isFile bool) {
+	parent, index := current.node, len(current.node.Children)
+	if current.node.IsFile {
+		if current.parent == nil {
+			e.message = "Error: cannot add to a file"
+			return
+		}
+		parent, index = current.parent, indexOf(current.parent, current.node)+1
+	}
+
+	label := "New file (end with / for a directory): "
+	if !isFile {
+		label = "New directory: "
+	}
+	e.ask(label, "", func(name string) error {
+		isFile := isFile
+		if strings.HasSuffix(name, "/") {
+			name, isFile = strings.TrimSuffix(name, "/"), false
+		}
+		if err := e.checkName(parent, nil, name); err != nil {
+			return err
+		}
+
+		e.snapshot()
+		node := &tree.Node{Name: name, IsFile: isFile}
+		parent.Children = append(parent.Children[:index], append([]*tree.Node{node}, parent.Children[index:]...)...)
+		e.selectNode(node)
+		return nil
+	})
+}
+
+func (e *Editor) startRename(current row) {
+	e.ask("Rename to: ", current.node.Name, func(name string) error {
+		if name == current.node.Name {
+			return nil
+		}
+		if err := e.checkName(current.parent, current.node, name); err != nil {
+			return err
+		}
+		e.snapshot()
+		current.node.Name = name
+		return nil
+	})
+}
+
+func (e *Editor) remove(current row) {
+	if current.parent == nil {
+		e.message = "Error: the root cannot be deleted"
+		return
+	}
+	e.snapshot()
+	current.parent.Children = without(current.parent.Children, current.node)
+	e.message = "Deleted " + current.node.Name + ", u to undo"
+}
+
+func (e *Editor) toggle(current row) {
+	node := current.node
+	switch {
+	case current.parent == nil:
+		e.message = "Error: the root is always a directory"
+		return
+	case !node.IsFile && len(node.Children) > 0:
+		e.message = fmt.Sprintf("Error: %s is not empty", node.Name)
+		return
+	}
+	e.snapshot()
+	node.IsFile = !node.IsFile
+	if !node.IsFile {
+		node.Content = ""
+	}
+}
+
+// moveSibling swaps the node with its previous or next sibling
+func (e *Editor) moveSibling(current row, delta int) {
+	if current.parent == nil {
+		return
+	}
+	siblings := current.parent.Children
+	i := indexOf(current.parent, current.node)
+	j := i + delta
+	if j < 0 || j >= len(siblings) {
+		return
+	}
+	e.snapshot()
+	siblings[i], siblings[j] = siblings[j], siblings[i]
+	e.selectNode(current.node)
+}
+
+// outdent moves the node out of its directory, right after it
+func (e *Editor) outdent(current row) {
+	if current.parent == nil || current.parent == e.root {
+		return
+	}
+	grandparent := parentOf(e.root, current.parent)
+	if err := e.checkName(grandparent, nil, current.node.Name); err != nil {
+		e.message = "Error: " + err.Error()
+		return
+	}
+
+	e.snapshot()
+	current.parent.Children = without(current.parent.Children, current.node)
+	index := indexOf(grandparent, current.parent) + 1
+	grandparent.Children = append(grandparent.Children[:index], append([]*tree.Node{current.node}, grandparent.Children[index:]...)...)
+	e.selectNode(current.node)
+}
+
+// indentNode moves the node into the directory right above it
+func (e *Editor) indentNode(current row) {
+	if current.parent == nil {
+		return
+	}
+	i := indexOf(current.parent, current.node)
+	if i == 0 || current.parent.Children[i-1].IsFile {
+		e.message = "Error: there is no directory above to move into"
+		return
+	}
+	target := current.parent.Children[i-1]
+	if err := e.checkName(target, nil, current.node.Name); err != nil {
+		e.message = "Error: " + err.Error()
+		return
+	}
+
+	e.snapshot()
+	current.parent.Children = without(current.parent.Children, current.node)
+	target.Children = append(target.Children, current.node)
+	e.selectNode(current.node)
+}
+
+// checkName validates a name for a child of parent, self being the node renamed
+func (e *Editor) checkName(parent, self *tree.Node, name string) error {
+	if err := tree.CheckName(name, self != nil && self == e.root); err != nil {
+		return err
+	}
+	if parent == nil {
+		return nil
+	}
+	for _, child := range parent.Children {
+		if child != self && child.Name == name {
+			return fmt.Errorf("%s already exists", tree.Join(parent.Name, name))
+		}
+	}
+	return nil
+}
+
+func (e *Editor) startSave(as bool) {
+	if e.path != "" && !as {
+		e.save(e.path, e.format)
+		return
+	}
+	e.ask("Save as (the extension picks the format): ", e.path, func(path string) error {
+		if path == "" {
+			return errors.New("a file name is required")
+		}
+		e.save(path, seed.DetectFormat(path, e.format))
+		return nil
+	})
+}
+
+func (e *Editor) save(path string, format seed.Format) {
+	var buf bytes.Buffer
+	if err := seed.NewTree(e.root).Write(&buf, format, seed.WithIndent(e.indent)); err != nil {
+		e.message = "Error: " + err.Error()
+		return
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		e.message = "Error: " + err.Error()
+		return
+	}
+	e.path, e.format, e.dirty, e.quitting = path, format, false, false
+	e.message = fmt.Sprintf("Saved %s as %s", path, format)
+}
+
+// cycleFormat switches to the next writable format for the next save
+func (e *Editor) cycleFormat() {
+	names := formats.Names(formats.Format.CanWrite)
+	next := names[0]
+	for i, name := range names {
+		if name == string(e.format) && i+1 < len(names) {
+			next = names[i+1]
+		}
+	}
+	e.format = seed.Format(next)
+	e.dirty = true
+	e.message = "Saving as " + next
+}
+
+// target is the directory the root is planted as
+func (e *Editor) target() string {
+	if e.root.Name == "." {
+		return e.dest
+	}
+	return filepath.Join(e.dest, e.root.Name)
+}
+
+// changes compares the tree with the target directory, a missing target
+// being an empty one
+func (e *Editor) changes() []tree.Change {
+	target := e.target()
+	actual, ok := e.harvested[target]
+	if !ok {
+		actual = tree.NewDir(".")
+		if harvested, err := seed.Harvest(target, seed.WithHidden()); err == nil {
+			// like seed diff, the repository itself is not part of the layout
+			harvested.Prune(".git")
+			actual = harvested.Node
+		}
+		e.harvested[target] = actual
+	}
+	return tree.Diff(e.root, actual)
+}
+
+func indexOf(parent, node *tree.Node) int {
+	for i, child := range parent.Children {
+		if child == node {
+			return i
+		}
+	}
+	return -1
+}
+
+func without(nodes []*tree.Node, node *tree.Node) []*tree.Node {
+	kept := make([]*tree.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n != node {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// parentOf finds the directory holding node, nil for the root
+func parentOf(root, node *tree.Node) *tree.Node {
+	for _, child := range root.Children {
+		if child == node {
+			return root
+		}
+		if parent := parentOf(child, node); parent != nil {
+			return parent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEditor(t *testing.T) *Editor {
+	root := tree.NewDir("app",
+		tree.NewDir("src", tree.NewFile("main.go")),
+		tree.NewFile("README.md"),
+	)
+	return New(root, filepath.Join(t.TempDir(), "layout.yaml"), WithDest(t.TempDir()), WithFormat("yaml"))
+}
+
+var keyNames = map[string]bool{
+	KeyUp: true, KeyDown: true, KeyLeft: true, KeyRight: true, KeyHome: true, KeyEnd: true,
+	KeyEnter: true, KeyEscape: true, KeyBackspace: true, KeyDelete: true, KeyCtrlC: true,
+}
+
+// press feeds each key, typing out anything else longer than one character
+func press(e *Editor, keys ...string) {
+	for _, key := range keys {
+		if !keyNames[key] && len([]rune(key)) > 1 {
+			for _, r := range key {
+				e.HandleKey(string(r))
+			}
+			continue
+		}
+		e.HandleKey(key)
+	}
+}
+
+func names(n *tree.Node) []string {
+	var names []string
+	n.Visit(func(path string, _ *tree.Node) bool {
+		names = append(names, path)
+		return true
+	})
+	return names
+}
+
+func TestAddRenameDelete(t *testing.T) {
+	e := newTestEditor(t)
+
+	// on a directory, a adds inside it
+	press(e, "j", "a", "util.go", KeyEnter)
+	assert.Equal(t, []string{"", "src", "src/main.go", "src/util.go", "README.md"}, names(e.Root()))
+
+	// on a file, next to it, and a trailing slash makes a directory
+	press(e, "a", "internal/", KeyEnter)
+	assert.Equal(t, "src/internal", names(e.Root())[4])
+	assert.False(t, e.Root().Find("src/internal").IsFile)
+
+	press(e, "r", KeyBackspace, KeyBackspace, KeyBackspace, KeyBackspace, KeyBackspace, KeyBackspace, KeyBackspace, KeyBackspace, "pkg", KeyEnter)
+	assert.NotNil(t, e.Root().Find("src/pkg"))
+
+	press(e, "d")
+	assert.Nil(t, e.Root().Find("src/pkg"))
+
+	press(e, "u")
+	assert.NotNil(t, e.Root().Find("src/pkg"))
+}
+
+func TestInvalidNames(t *testing.T) {
+	e := newTestEditor(t)
+
+	press(e, "a", "README.md", KeyEnter)
+	assert.Contains(t, e.status(), "app/README.md already exists")
+
+	press(e, "a", "a/b", KeyEnter)
+	assert.Contains(t, e.status(), "cannot contain a path separator")
+
+	// the root alone may be named .
+	press(e, "r", KeyBackspace, KeyBackspace, KeyBackspace, ".", KeyEnter)
+	assert.Equal(t, ".", e.Root().Name)
+
+	press(e, "d")
+	assert.Contains(t, e.status(), "the root cannot be deleted")
+}
+
+func TestMoveAndToggle(t *testing.T) {
+	e := newTestEditor(t)
+
+	// README.md up above src, then into nothing since a file is above it
+	press(e, "G", "K")
+	assert.Equal(t, []string{"", "README.md", "src", "src/main.go"}, names(e.Root()))
+
+	// main.go out of src, then back in
+	press(e, "G", "H")
+	assert.Equal(t, []string{"", "README.md", "src", "main.go"}, names(e.Root()))
+	press(e, "L")
+	assert.Equal(t, []string{"", "README.md", "src", "src/main.go"}, names(e.Root()))
+
+	press(e, "k", "t")
+	assert.Contains(t, e.status(), "src is not empty")
+	press(e, "k", "t")
+	assert.False(t, e.Root().Find("README.md").IsFile)
+}
+
+func TestSaveAndQuit(t *testing.T) {
+	e := newTestEditor(t)
+	press(e, "a", "go.mod", KeyEnter)
+
+	press(e, "q")
+	assert.False(t, e.Done(), "unsaved changes need a second q")
+
+	press(e, "w")
+	data, err := os.ReadFile(e.path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "name: go.mod")
+
+	// saving as another extension switches the format
+	path := filepath.Join(filepath.Dir(e.path), "layout.json")
+	press(e, "W")
+	e.prompt.text = path
+	press(e, KeyEnter)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"name": "go.mod"`)
+	assert.Contains(t, e.header(), "(json)")
+
+	press(e, "q")
+	assert.True(t, e.Done())
+}
+
+func TestView(t *testing.T) {
+	e := newTestEditor(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(e.dest, "app", "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(e.dest, "app", "src", "main.go"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(e.dest, "app", "notes.txt"), nil, 0644))
+
+	lines := e.View(100, 10)
+	require.Len(t, lines, 10)
+	assert.Equal(t, []string{
+		"seed edit: " + e.path + " (yaml)",
+		"> app/  (existing)",
+		"  ├── src/  (existing)",
+		"  │   └── main.go  (conflict)",
+		"  └── README.md",
+	}, lines[:5])
+	assert.Equal(t, "── "+filepath.Join(e.dest, "app")+": 1 to create, 0 mismatched, 1 only on disk", lines[7])
+	assert.Equal(t, "+ README.md", lines[8])
+	assert.Equal(t, help, lines[9])
+
+	// collapsed directories count their children
+	press(e, "j", KeyEnter)
+	assert.Equal(t, "> ├── src/ (+1)  (existing)", e.View(100, 10)[2])
+	assert.Equal(t, "seed edit…", e.View(10, 10)[0])
+}
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t,
+		[]string{"a", KeyUp, KeyDown, KeyEnter, KeyBackspace, KeyDelete, KeyEscape, "é", KeyCtrlC},
+		ParseKeys([]byte("a\x1b[A\x1bOB\r\x7f\x1b[3~\x1bé\x03")),
+	)
+	// unknown sequences are dropped
+	assert.Equal(t, []string{"x"}, ParseKeys([]byte("\x1b[15~x")))
+}
@@ -0,0 +1,144 @@
+package editor
+
+import (
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// Run takes over the terminal of stdin and stdout until the user quits. The
+// terminal is put in raw mode and restored on the way out, even when drawing
+// fails.
+func Run(e *Editor) error {
+	in, out := os.Stdin, os.Stdout
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(in.Fd()), state)
+
+	if _, err := out.WriteString(enterScreen); err != nil {
+		return err
+	}
+	defer out.WriteString(leaveScreen)
+
+	buf := make([]byte, 256)
+	for !e.Done() {
+		width, height := size(out)
+		if err := draw(out, e.View(width, height)); err != nil {
+			return err
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range ParseKeys(buf[:n]) {
+			e.HandleKey(key)
+			if e.Done() {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// draw repaints the whole screen, clearing what is left of each line
+func draw(tty *os.File, lines []string) error {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	_, err := tty.WriteString(b.String())
+	return err
+}
+
+// size asks the terminal for its size, falling back to 80x24
+func size(tty *os.File) (width, height int) {
+	width, height, err := term.GetSize(int(tty.Fd()))
+	if err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// escapes maps the escape sequences of xterm compatible terminals to key names
+var escapes = map[string]string{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1b[C":  KeyRight,
+	"\x1b[D":  KeyLeft,
+	"\x1bOA":  KeyUp,
+	"\x1bOB":  KeyDown,
+	"\x1bOC":  KeyRight,
+	"\x1bOD":  KeyLeft,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+	"\x1b[3~": KeyDelete,
+}
+
+// ParseKeys splits raw terminal input into key names for HandleKey. Unknown
+// escape sequences are dropped.
+func ParseKeys(input []byte) []string {
+	var keys []string
+	for len(input) > 0 {
+		switch c := input[0]; {
+		case c == 0x1b:
+			n := escapeLength(input)
+			if n == 1 {
+				keys = append(keys, KeyEscape)
+			} else if key, ok := escapes[string(input[:n])]; ok {
+				keys = append(keys, key)
+			}
+			input = input[n:]
+			continue
+		case c == '\r' || c == '\n':
+			keys = append(keys, KeyEnter)
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, KeyBackspace)
+		case c == 0x03:
+			keys = append(keys, KeyCtrlC)
+		case c < 0x20:
+			// other control characters have no binding
+		default:
+			r, n := utf8.DecodeRune(input)
+			if r != utf8.RuneError {
+				keys = append(keys, string(r))
+			}
+			input = input[n:]
+			continue
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+// escapeLength is the length of the escape sequence at the start of input,
+// 1 for a lone escape
+func escapeLength(input []byte) int {
+	if len(input) < 2 || (input[1] != '[' && input[1] != 'O') {
+		return 1
+	}
+	for i := 2; i < len(input); i++ {
+		// a CSI sequence ends with a byte in @-~
+		if input[i] >= 0x40 && input[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(input)
+}
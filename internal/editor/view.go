@@ -0,0 +1,171 @@
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+const (
+	colorReset   = "\033[0m"
+	colorReverse = "\033[7m"
+	colorBold    = "\033[1m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorRed     = "\033[31m"
+)
+
+// minHeight leaves room for the header, a line of tree, the diff and the status line
+const minHeight = 5
+
+// View renders the screen as lines of at most width characters, the header
+// first, then the tree, the diff against the destination and the status line.
+func (e *Editor) View(width, height int) []string {
+	if height < minHeight {
+		height = minHeight
+	}
+
+	diff := e.diffLines(width, height/4)
+	treeHeight := height - 2 - len(diff)
+
+	lines := []string{e.paint(truncate(e.header(), width), colorBold)}
+	lines = append(lines, e.treeLines(width, treeHeight)...)
+	lines = append(lines, diff...)
+	return append(lines, truncate(e.status(), width))
+}
+
+func (e *Editor) header() string {
+	name := e.path
+	if name == "" {
+		name = "[new seed]"
+	}
+	header := fmt.Sprintf("seed edit: %s (%s)", name, e.format)
+	if e.dirty {
+		header += " [modified]"
+	}
+	return header
+}
+
+// treeLines renders the visible rows, scrolled so the cursor stays in view
+func (e *Editor) treeLines(width, height int) []string {
+	rows := e.rows()
+	if e.cursor < e.offset {
+		e.offset = e.cursor
+	}
+	if e.cursor >= e.offset+height {
+		e.offset = e.cursor - height + 1
+	}
+
+	statuses := make(map[*tree.Node]seed.Status)
+	for _, p := range seed.Preview(seed.NewTree(e.root), e.dest) {
+		statuses[p.Node] = p.Status
+	}
+
+	lines := make([]string, 0, height)
+	for i := e.offset; i < len(rows) && len(lines) < height; i++ {
+		r := rows[i]
+		name := r.node.Name
+		if !r.node.IsFile {
+			name += "/"
+		}
+		if e.collapsed[r.node] {
+			name += fmt.Sprintf(" (+%d)", len(r.node.Children))
+		}
+		if status := statuses[r.node]; status != seed.StatusNew {
+			name += "  (" + string(status) + ")"
+		}
+
+		marker := "  "
+		if i == e.cursor {
+			marker = "> "
+		}
+		line := truncate(marker+r.prefix+name, width)
+
+		switch {
+		case i == e.cursor:
+			line = e.paint(line, colorReverse)
+		case statuses[r.node] == seed.StatusNew:
+			line = e.paint(line, colorGreen)
+		case statuses[r.node] == seed.StatusConflict:
+			line = e.paint(line, colorYellow)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// diffLines summarises what planting would change in the target directory,
+// listing at most limit paths
+func (e *Editor) diffLines(width, limit int) []string {
+	type change struct {
+		text  string
+		color string
+	}
+	var created, mismatched, extra int
+	var listed []change
+	for _, c := range e.changes() {
+		switch c.Kind {
+		case tree.Missing:
+			created++
+			listed = append(listed, change{"+ " + displayPath(c.Path, c.Expected), colorGreen})
+		case tree.Mismatched:
+			mismatched++
+			listed = append(listed, change{fmt.Sprintf("! %s (%s in the seed, %s on disk)", c.Path, c.Expected, c.Actual), colorRed})
+		case tree.Extra:
+			extra++
+		}
+	}
+	if len(listed) > limit {
+		more := len(listed) - limit + 1
+		listed = append(listed[:limit-1], change{text: fmt.Sprintf("  … and %d more", more)})
+	}
+
+	lines := []string{truncate(fmt.Sprintf("── %s: %d to create, %d mismatched, %d only on disk", e.target(), created, mismatched, extra), width)}
+	for _, c := range listed {
+		text := truncate(c.text, width)
+		if c.color != "" {
+			text = e.paint(text, c.color)
+		}
+		lines = append(lines, text)
+	}
+	return lines
+}
+
+func (e *Editor) status() string {
+	switch {
+	case e.prompt != nil:
+		return e.prompt.label + e.prompt.text + "█"
+	case e.message != "":
+		return e.message
+	default:
+		return help
+	}
+}
+
+func (e *Editor) paint(text, color string) string {
+	if !e.color {
+		return text
+	}
+	return color + text + colorReset
+}
+
+func displayPath(path, kind string) string {
+	if kind == "directory" {
+		return path + "/"
+	}
+	return path
+}
+
+// truncate cuts s to width characters, colour codes being added afterwards
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	return strings.TrimRight(string(runes[:width-1]), " ") + "…"
+}
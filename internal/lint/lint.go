@@ -0,0 +1,253 @@
+// Package lint checks the names in a seed against naming rules before
+// anything is planted.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jpwallace22/seed/pkg/ignore"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type Severity string
+
+const (
+	// Error violations fail seed lint and stop planting
+	Error Severity = "error"
+	// Warning violations are reported and nothing more
+	Warning Severity = "warning"
+)
+
+// Rule selects nodes by path, depth and type, then applies each of the checks
+// it sets to them. Selectors that are left out match every node but the root,
+// which stands for the destination.
+type Rule struct {
+	Name     string   `yaml:"name" json:"name"`
+	Severity Severity `yaml:"severity,omitempty" json:"severity,omitempty"`
+
+	// Paths are gitignore style patterns, later ones taking precedence, so
+	// `!*_test.go` leaves test files out of `*.go`
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// Depth is 1 for the children of the root
+	Depth *int `yaml:"depth,omitempty" json:"depth,omitempty"`
+	// Type is file or directory
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Pattern is a regular expression every name has to match in full
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	// Forbidden lists characters names may not contain
+	Forbidden string `yaml:"forbidden,omitempty" json:"forbidden,omitempty"`
+	// MaxLength is the longest a name may be, in characters
+	MaxLength int `yaml:"max-length,omitempty" json:"max-length,omitempty"`
+	// Sibling names a node that has to exist next to every matching one.
+	// {name}, {stem} and {ext} stand for the matching name, the name without
+	// its extension and the extension, so {stem}_test.go pairs foo.go with
+	// foo_test.go
+	Sibling string `yaml:"sibling,omitempty" json:"sibling,omitempty"`
+}
+
+// Violation is a node that breaks a rule.
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Path     string   `json:"path"`
+	// Line is where the node was read in the seed, zero when unknown
+	Line int `json:"line,omitempty"`
+	// Message follows the path, as in "main.go has no sibling main_test.go"
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s (%s)", v.Path, v.Message, v.Rule)
+}
+
+// Linter applies a set of rules.
+type Linter struct {
+	rules    []rule
+	portable bool
+}
+
+type rule struct {
+	Rule
+	paths   *ignore.Matcher
+	pattern *regexp.Regexp
+}
+
+// New checks the rules, which are applied in order.
+func New(rules []Rule, opts ...Option) (*Linter, error) {
+	l := &Linter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	for i, r := range rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		compiled, err := compile(r)
+		if err != nil {
+			return nil, fmt.Errorf("lint rule %q: %w", r.Name, err)
+		}
+		l.rules = append(l.rules, compiled)
+	}
+	return l, nil
+}
+
+func compile(r Rule) (rule, error) {
+	switch r.Severity {
+	case "":
+		r.Severity = Error
+	case Error, Warning:
+	default:
+		return rule{}, fmt.Errorf("invalid severity %q, must be one of: error, warning", r.Severity)
+	}
+	switch r.Type {
+	case "", "file", "directory":
+	default:
+		return rule{}, fmt.Errorf("invalid type %q, must be one of: file, directory", r.Type)
+	}
+	if r.Pattern == "" && r.Forbidden == "" && r.MaxLength == 0 && r.Sibling == "" {
+		return rule{}, errors.New("a pattern, forbidden, max-length or sibling check is required")
+	}
+	if r.MaxLength < 0 {
+		return rule{}, fmt.Errorf("invalid max-length %d", r.MaxLength)
+	}
+
+	c := rule{Rule: r}
+	if len(r.Paths) > 0 {
+		c.paths = ignore.New(r.Paths...)
+	}
+	if r.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + r.Pattern + ")$")
+		if err != nil {
+			return rule{}, fmt.Errorf("invalid pattern: %w", err)
+		}
+		c.pattern = re
+	}
+	return c, nil
+}
+
+// Empty reports whether there is nothing to check.
+func (l *Linter) Empty() bool {
+	return len(l.rules) == 0 && !l.portable
+}
+
+// Check returns the violations of every rule, in seed order.
+func (l *Linter) Check(root *tree.Node) []Violation {
+	// portable paths are measured from the destination, the root included
+	// unless it is the destination itself
+	base := ""
+	if root.Name != "." {
+		base = root.Name
+	}
+
+	var violations []Violation
+	// the rules leave the root alone, but it is planted under its own name all the same
+	if l.portable && base != "" {
+		violations = append(violations, portableName(root, base, base, false)...)
+	}
+
+	var walk func(parent *tree.Node, dir string, depth int)
+	walk = func(parent *tree.Node, dir string, depth int) {
+		var collided map[*tree.Node]Violation
+		if l.portable {
+			collided = collisions(parent, dir)
+		}
+		for _, node := range parent.Children {
+			path := tree.Join(dir, node.Name)
+			if l.portable {
+				full := tree.Join(base, path)
+				parentTooLong := pathLength(tree.Join(base, dir)) > MaxPathLength
+				violations = append(violations, portableName(node, path, full, parentTooLong)...)
+				if v, ok := collided[node]; ok {
+					violations = append(violations, v)
+				}
+			}
+			for _, r := range l.rules {
+				if r.selects(path, node, depth) {
+					violations = append(violations, r.check(parent, node, path)...)
+				}
+			}
+			walk(node, path, depth+1)
+		}
+	}
+	walk(root, "", 1)
+	return violations
+}
+
+func (r rule) selects(path string, node *tree.Node, depth int) bool {
+	if r.Depth != nil && *r.Depth != depth {
+		return false
+	}
+	if r.Type != "" && r.Type != node.Kind() {
+		return false
+	}
+	if r.paths == nil || r.paths.Match(path, !node.IsFile) {
+		return true
+	}
+	// as in a .gitignore, a matching directory takes everything inside along
+	for dir := path; strings.Contains(dir, "/"); {
+		dir = dir[:strings.LastIndex(dir, "/")]
+		if r.paths.Match(dir, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r rule) check(parent, node *tree.Node, path string) []Violation {
+	var violations []Violation
+	report := func(format string, v ...interface{}) {
+		violations = append(violations, Violation{
+			Rule:     r.Name,
+			Severity: r.Severity,
+			Path:     path,
+			Line:     node.Line,
+			Message:  fmt.Sprintf(format, v...),
+		})
+	}
+
+	name := node.Name
+	if r.pattern != nil && !r.pattern.MatchString(name) {
+		report("does not match %s", r.Pattern)
+	}
+	if i := strings.IndexAny(name, r.Forbidden); r.Forbidden != "" && i >= 0 {
+		c, _ := utf8.DecodeRuneInString(name[i:])
+		report("contains the forbidden character %q", c)
+	}
+	if n := utf8.RuneCountInString(name); r.MaxLength > 0 && n > r.MaxLength {
+		report("is %d characters long, the limit is %d", n, r.MaxLength)
+	}
+	if r.Sibling != "" {
+		sibling := siblingName(r.Sibling, name)
+		if parent.Child(sibling) == nil {
+			report("has no sibling %s", sibling)
+		}
+	}
+	return violations
+}
+
+func siblingName(template, name string) string {
+	ext := path.Ext(name)
+	return strings.NewReplacer(
+		"{name}", name,
+		"{stem}", strings.TrimSuffix(name, ext),
+		"{ext}", ext,
+	).Replace(template)
+}
+
+// Count returns the number of violations of each severity.
+func Count(violations []Violation) (errs, warnings int) {
+	for _, v := range violations {
+		if v.Severity == Error {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+	return errs, warnings
+}
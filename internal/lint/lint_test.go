@@ -0,0 +1,73 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintTree() *tree.Node {
+	file := func(name string, line int) *tree.Node {
+		n := tree.NewFile(name)
+		n.Line = line
+		return n
+	}
+	pkg := tree.NewDir("pkg", file("parse.go", 3), file("parse_test.go", 4), file("Util.go", 5))
+	pkg.Line = 2
+	cmd := tree.NewDir("cmd_Tools", file("main.go", 7))
+	cmd.Line = 6
+	return tree.NewDir("MyApp", pkg, cmd, file("notes about it.md", 8))
+}
+
+func one(n int) *int {
+	return &n
+}
+
+func TestCheck(t *testing.T) {
+	l, err := New([]Rule{
+		{Name: "kebab-dirs", Type: "directory", Pattern: "[a-z0-9]+(-[a-z0-9]+)*"},
+		{Name: "snake-go", Paths: []string{"*.go"}, Pattern: `[a-z0-9_]+\.go`},
+		{Name: "tests", Severity: Warning, Paths: []string{"*.go", "!*_test.go", "!cmd_Tools/**"}, Sibling: "{stem}_test{ext}"},
+		{Name: "no-spaces", Depth: one(1), Forbidden: " "},
+		{Name: "short", MaxLength: 12},
+		{Name: "cmd", Paths: []string{"cmd_Tools/"}, Pattern: "cmd_.*"},
+	})
+	require.NoError(t, err)
+
+	var got []string
+	for _, v := range l.Check(lintTree()) {
+		got = append(got, string(v.Severity)+" "+v.String())
+		assert.NotZero(t, v.Line)
+	}
+	assert.Equal(t, []string{
+		"error pkg/parse_test.go is 13 characters long, the limit is 12 (short)",
+		"error pkg/Util.go does not match [a-z0-9_]+\\.go (snake-go)",
+		"warning pkg/Util.go has no sibling Util_test.go (tests)",
+		"error cmd_Tools does not match [a-z0-9]+(-[a-z0-9]+)* (kebab-dirs)",
+		"error cmd_Tools/main.go does not match cmd_.* (cmd)",
+		"error notes about it.md contains the forbidden character ' ' (no-spaces)",
+		"error notes about it.md is 17 characters long, the limit is 12 (short)",
+	}, got)
+
+	errs, warnings := Count(l.Check(lintTree()))
+	assert.Equal(t, 6, errs)
+	assert.Equal(t, 1, warnings)
+}
+
+func TestInvalidRules(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		err  string
+	}{
+		{Rule{Pattern: "[a-z"}, `lint rule "rule 1": invalid pattern`},
+		{Rule{Name: "x", Severity: "fatal", MaxLength: 1}, "invalid severity"},
+		{Rule{Name: "x", Type: "link", MaxLength: 1}, "invalid type"},
+		{Rule{Name: "x", Paths: []string{"*.go"}}, "check is required"},
+	}
+	for _, tt := range tests {
+		_, err := New([]Rule{tt.rule})
+		assert.ErrorContains(t, err, tt.err)
+	}
+}
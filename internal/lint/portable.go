@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Names of the checks of WithPortable
+const (
+	RuleReservedName     = "windows-reserved-name"
+	RuleTrailingChar     = "windows-trailing-char"
+	RuleInvalidChar      = "windows-invalid-char"
+	RulePathLength       = "windows-path-length"
+	RuleCaseCollision    = "case-collision"
+	RuleUnicodeCollision = "unicode-collision"
+)
+
+// MaxPathLength is the MAX_PATH of Windows, counted in UTF-16 code units
+const MaxPathLength = 260
+
+// invalidChars cannot be used in Windows file names, on top of control characters
+const invalidChars = `<>:"|?*\`
+
+// Option configures a Linter.
+type Option func(*Linter)
+
+// WithPortable also flags names that cannot be planted or checked out on
+// every platform: Windows reserved names, invalid characters and trailing dots
+// or spaces, paths longer than MaxPathLength, and siblings that collide on
+// case-insensitive filesystems or once normalized to the same Unicode form.
+// Paths are measured from the destination, so the limit is reached sooner in
+// practice.
+func WithPortable() Option {
+	return func(l *Linter) {
+		l.portable = true
+	}
+}
+
+// portableName checks a single node at path, full being the same path from the destination
+func portableName(node *tree.Node, path, full string, parentTooLong bool) []Violation {
+	var violations []Violation
+	report := func(rule string, severity Severity, format string, v ...interface{}) {
+		violations = append(violations, Violation{
+			Rule:     rule,
+			Severity: severity,
+			Path:     path,
+			Line:     node.Line,
+			Message:  fmt.Sprintf(format, v...),
+		})
+	}
+
+	name := node.Name
+	if reservedName(name) {
+		report(RuleReservedName, Error, "is a reserved name on Windows")
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		report(RuleTrailingChar, Error, "ends with a %q, which Windows drops", name[len(name)-1:])
+	}
+	for _, c := range name {
+		if c < 0x20 || strings.ContainsRune(invalidChars, c) {
+			report(RuleInvalidChar, Error, "contains %q, which Windows does not allow", c)
+			break
+		}
+	}
+	// only the first path over the limit is reported, not everything below it
+	if n := pathLength(full); n > MaxPathLength && !parentTooLong {
+		report(RulePathLength, Warning, "is %d characters long, over the %d of Windows", n, MaxPathLength)
+	}
+	return violations
+}
+
+// reservedName reports device names, which Windows reserves whatever their extension
+func reservedName(name string) bool {
+	stem, _, _ := strings.Cut(name, ".")
+	switch stem = strings.ToUpper(strings.TrimRight(stem, " ")); stem {
+	case "CON", "PRN", "AUX", "NUL":
+		return true
+	}
+	if len(stem) == 4 && (strings.HasPrefix(stem, "COM") || strings.HasPrefix(stem, "LPT")) {
+		return stem[3] >= '1' && stem[3] <= '9'
+	}
+	return false
+}
+
+func pathLength(path string) int {
+	return len(utf16.Encode([]rune(path)))
+}
+
+// collisions flags the children of a directory that end up with the same name
+// on macOS or Windows, reporting the later one
+func collisions(parent *tree.Node, dir string) map[*tree.Node]Violation {
+	violations := map[*tree.Node]Violation{}
+	normalized := make(map[string]string, len(parent.Children))
+	folded := make(map[string]string, len(parent.Children))
+
+	for _, child := range parent.Children {
+		name := norm.NFC.String(child.Name)
+		lower := strings.ToLower(name)
+		report := func(rule, format string, v ...interface{}) {
+			violations[child] = Violation{
+				Rule:     rule,
+				Severity: Error,
+				Path:     tree.Join(dir, child.Name),
+				Line:     child.Line,
+				Message:  fmt.Sprintf(format, v...),
+			}
+		}
+
+		switch first, ok := normalized[name]; {
+		case ok && first != child.Name:
+			report(RuleUnicodeCollision, "is the same name as %s once Unicode normalized", first)
+		case !ok:
+			if first, ok := folded[lower]; ok {
+				report(RuleCaseCollision, "collides with %s on case-insensitive filesystems", first)
+			}
+		}
+
+		if _, ok := normalized[name]; !ok {
+			normalized[name] = child.Name
+		}
+		if _, ok := folded[lower]; !ok {
+			folded[lower] = child.Name
+		}
+	}
+	return violations
+}
@@ -0,0 +1,98 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkPortable(t *testing.T, root *tree.Node) []string {
+	t.Helper()
+	l, err := New(nil, WithPortable())
+	require.NoError(t, err)
+	assert.False(t, l.Empty())
+
+	var got []string
+	for _, v := range l.Check(root) {
+		got = append(got, string(v.Severity)+" "+v.String())
+	}
+	return got
+}
+
+func TestPortableNames(t *testing.T) {
+	root := tree.NewDir("app",
+		tree.NewFile("CON"),
+		tree.NewFile("nul.txt"),
+		tree.NewDir("com1"),
+		tree.NewFile("COM0"),
+		tree.NewFile("console.log"),
+		tree.NewFile("notes."),
+		tree.NewDir("docs "),
+		tree.NewFile("a:b?.txt"),
+		tree.NewFile("tab\there"),
+		tree.NewFile("ok.go"),
+	)
+
+	assert.Equal(t, []string{
+		"error CON is a reserved name on Windows (windows-reserved-name)",
+		"error nul.txt is a reserved name on Windows (windows-reserved-name)",
+		"error com1 is a reserved name on Windows (windows-reserved-name)",
+		`error notes. ends with a ".", which Windows drops (windows-trailing-char)`,
+		`error docs  ends with a " ", which Windows drops (windows-trailing-char)`,
+		"error a:b?.txt contains ':', which Windows does not allow (windows-invalid-char)",
+		"error tab\there contains '\\t', which Windows does not allow (windows-invalid-char)",
+	}, checkPortable(t, root))
+}
+
+func TestPortableRoot(t *testing.T) {
+	assert.Equal(t, []string{
+		"error CON is a reserved name on Windows (windows-reserved-name)",
+	}, checkPortable(t, tree.NewDir("CON", tree.NewFile("main.go"))))
+	assert.Equal(t, []string{
+		"error a:b contains ':', which Windows does not allow (windows-invalid-char)",
+	}, checkPortable(t, tree.NewDir("a:b")))
+	assert.Empty(t, checkPortable(t, tree.NewDir(".", tree.NewFile("main.go"))))
+}
+
+func TestPortablePathLength(t *testing.T) {
+	long := strings.Repeat("d", 200)
+	root := tree.NewDir("app",
+		tree.NewDir(long,
+			tree.NewFile(strings.Repeat("f", 50)+".go"),
+			tree.NewDir(strings.Repeat("e", 59), tree.NewFile("deep.go")),
+		),
+	)
+
+	got := checkPortable(t, root)
+	require.Len(t, got, 1, "only the first path over the limit is reported")
+	assert.Equal(t, "warning "+long+"/"+strings.Repeat("e", 59)+
+		" is 264 characters long, over the 260 of Windows (windows-path-length)", got[0])
+
+	// planted into the current directory, the root adds nothing
+	root.Name = "."
+	assert.Equal(t, []string{
+		"warning " + long + "/" + strings.Repeat("e", 59) + "/deep.go is 268 characters long, over the 260 of Windows (windows-path-length)",
+	}, checkPortable(t, root))
+}
+
+func TestPortableCollisions(t *testing.T) {
+	root := tree.NewDir("app",
+		tree.NewFile("README.md"),
+		tree.NewFile("Readme.md"),
+		tree.NewDir("src",
+			tree.NewFile("café.go"),
+			tree.NewFile("café.go"),
+			tree.NewFile("Café.go"),
+		),
+		tree.NewFile("readme.txt"),
+	)
+
+	assert.Equal(t, []string{
+		"error Readme.md collides with README.md on case-insensitive filesystems (case-collision)",
+		"error src/café.go is the same name as café.go once Unicode normalized (unicode-collision)",
+		"error src/Café.go collides with café.go on case-insensitive filesystems (case-collision)",
+	}, checkPortable(t, root))
+}
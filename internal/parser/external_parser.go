@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// PluginPrefix is the name prefix of executables that act as parsers. An
+// executable `seed-parser-<name>` on $PATH makes `--format <name>` available.
+const PluginPrefix = "seed-parser-"
+
+// externalParser pipes the raw input to a plugin executable and reads back the
+// `tree -J` JSON it prints, so adapters can be written in any language.
+type externalParser struct {
+	path string
+}
+
+func NewExternalParser(path string) Parser {
+	return &externalParser{path: path}
+}
+
+// Parse kills the plugin when ctx is done.
+func (p *externalParser) Parse(ctx context.Context, input string) (*tree.Node, error) {
+	name := filepath.Base(p.path)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	root, err := NewJSONParser().Parse(ctx, stdout.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s returned invalid output: %w", name, err)
+	}
+	return root, nil
+}
+
+// findPlugin resolves a format name to a `seed-parser-<name>` executable on $PATH
+func findPlugin(name string) (formats.Format, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return formats.Format{}, false
+	}
+
+	path, err := exec.LookPath(PluginPrefix + name)
+	if err != nil {
+		return formats.Format{}, false
+	}
+
+	return formats.Format{
+		Name:      name,
+		NewParser: func() formats.Parser { return NewExternalParser(path) },
+	}, true
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installPlugins writes shell script plugins, keyed by format name, into a fresh $PATH
+func installPlugins(t *testing.T, scripts map[string]string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in this test")
+	}
+
+	dir := t.TempDir()
+	for name, script := range scripts {
+		path := filepath.Join(dir, PluginPrefix+name)
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestExternalParser(t *testing.T) {
+	// uses the first line of input as the name of a service directory
+	installPlugins(t, map[string]string{"catalog": `read line
+printf '[{"type":"directory","name":"%s","contents":[{"type":"file","name":"main.go"}]}]' "$line"
+`})
+
+	p, err := NewParser(WithFormat("catalog"))
+	require.NoError(t, err)
+
+	root, err := p.Parse(context.Background(), "payments\n")
+	require.NoError(t, err)
+	assert.Equal(t, "payments", root.Name)
+	assert.NotNil(t, root.Find("main.go"))
+}
+
+func TestExternalParserErrors(t *testing.T) {
+	installPlugins(t, map[string]string{
+		"broken":  "echo 'unknown service' >&2\nexit 3\n",
+		"garbage": "echo 'not json'\n",
+	})
+
+	p, err := NewParser(WithFormat("broken"))
+	require.NoError(t, err)
+	_, err = p.Parse(context.Background(), "x")
+	assert.ErrorContains(t, err, "seed-parser-broken failed")
+	assert.ErrorContains(t, err, "unknown service")
+
+	p, err = NewParser(WithFormat("garbage"))
+	require.NoError(t, err)
+	_, err = p.Parse(context.Background(), "x")
+	assert.ErrorContains(t, err, "seed-parser-garbage returned invalid output")
+}
+
+func TestFindPlugin(t *testing.T) {
+	installPlugins(t, map[string]string{"catalog": "true\n"})
+
+	f, ok := formats.Lookup("catalog")
+	require.True(t, ok)
+	assert.True(t, f.CanParse())
+	assert.False(t, f.CanWrite())
+
+	_, ok = formats.Lookup("missing")
+	assert.False(t, ok)
+	_, ok = findPlugin("../catalog")
+	assert.False(t, ok)
+}
+
+func TestExternalParserTimeout(t *testing.T) {
+	installPlugins(t, map[string]string{"slow": "exec sleep 10\n"})
+	t.Setenv("PATH", os.Getenv("PATH")+string(os.PathListSeparator)+"/bin"+string(os.PathListSeparator)+"/usr/bin")
+
+	p, err := NewParser(WithFormat("slow"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = p.Parse(ctx, "x")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -1,16 +1,33 @@
 package parser
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
-	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/tree"
 )
 
+// FileNode is the node shape of `tree -J`, shared with the YAML format. Mode,
+// comment, content and hooks are seed extensions the tree command never emits.
 type FileNode struct {
-	Type     string     `json:"type"`
-	Name     string     `json:"name"`
-	Contents []FileNode `json:"contents,omitempty"`
+	Type     string     `json:"type" yaml:"type,omitempty"`
+	Name     string     `json:"name" yaml:"name"`
+	Mode     string     `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Comment  string     `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Content  string     `json:"content,omitempty" yaml:"content,omitempty"`
+	Hooks    *FileHooks `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Contents []FileNode `json:"contents,omitempty" yaml:"contents,omitempty"`
+	// Line is where the node starts in the source, it is never written
+	Line int `json:"-" yaml:"-"`
+}
+
+// FileHooks are the commands run before and after planting
+type FileHooks struct {
+	Pre  []string `json:"pre,omitempty" yaml:"pre,omitempty"`
+	Post []string `json:"post,omitempty" yaml:"post,omitempty"`
 }
 
 type Report struct {
@@ -19,62 +36,60 @@ type Report struct {
 	Files       int    `json:"files"`
 }
 
-type jsonParser struct {
-	ctx *ctx.SeedContext
-}
+type jsonParser struct{}
 
-func NewJSONParser(ctx *ctx.SeedContext) Parser {
-	return &jsonParser{ctx: ctx}
+func NewJSONParser() Parser {
+	return &jsonParser{}
 }
 
-func (p *jsonParser) ParseTree(jsonStr string) error {
+func (p *jsonParser) Parse(ctx context.Context, jsonStr string) (*tree.Node, error) {
 	if jsonStr == "" {
-		return fmt.Errorf("no tree provided")
+		return nil, fmt.Errorf("no tree provided")
 	}
 
 	var nodes []json.RawMessage
 	if err := json.Unmarshal([]byte(jsonStr), &nodes); err != nil {
-		return fmt.Errorf("invalid JSON: %w", err)
+		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
 
 	if len(nodes) == 0 {
-		return fmt.Errorf("empty JSON array")
+		return nil, fmt.Errorf("empty JSON array")
 	}
 
 	// First validate the root node structure
 	if err := p.validateNode(nodes[0]); err != nil {
-		return fmt.Errorf("failed to parse tree: %w", err)
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
 	}
 
 	// Then parse into FileNode
 	var rootFileNode FileNode
 	if err := json.Unmarshal(nodes[0], &rootFileNode); err != nil {
-		return fmt.Errorf("failed to parse root node: %w", err)
+		return nil, fmt.Errorf("failed to parse root node: %w", err)
 	}
 
-	// Convert to TreeNode
-	rootTreeNode := p.fileNodeToTreeNode(&rootFileNode)
+	jsonLines(jsonStr, &rootFileNode)
 
-	// Create filesystem using the existing function
-	if err := createFileSystem(rootTreeNode, "", p.ctx.Logger); err != nil {
-		return fmt.Errorf("failed to create filesystem: %w", err)
+	// Convert to TreeNode
+	rootTreeNode, err := fileNodeToTreeNode(ctx, &rootFileNode, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
 	}
 
 	// Verify report if present
 	if len(nodes) > 1 {
 		var report Report
 		if err := json.Unmarshal(nodes[1], &report); err != nil {
-			return fmt.Errorf("failed to parse report: %w", err)
+			return nil, fmt.Errorf("failed to parse report: %w", err)
 		}
 
-		dirs, files := p.countTreeNodes(rootTreeNode)
+		dirs, files := rootTreeNode.Counts()
 		if dirs != report.Directories || files != report.Files {
-			return fmt.Errorf("file system count mismatch - expected: %d directories and %d files, got: %d directories and %d files",
+			return nil, fmt.Errorf("file system count mismatch - expected: %d directories and %d files, got: %d directories and %d files",
 				report.Directories, report.Files, dirs, files)
 		}
 	}
 
-	return nil
+	return rootTreeNode, nil
 }
 
 func (p *jsonParser) validateNode(raw json.RawMessage) error {
@@ -106,37 +121,216 @@ func (p *jsonParser) validateNode(raw json.RawMessage) error {
 	return nil
 }
 
-func (p *jsonParser) fileNodeToTreeNode(node *FileNode) *TreeNode {
-	treeNode := &TreeNode{
-		name:     node.Name,
-		isFile:   node.Type == "file",
-		children: make([]*TreeNode, 0),
+// fileNodeToTreeNode converts node and everything below it, isRoot telling
+// whether node is the root of the seed
+func fileNodeToTreeNode(ctx context.Context, node *FileNode, isRoot bool) (*tree.Node, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := tree.CheckName(node.Name, isRoot); err != nil {
+		return nil, err
+	}
+
+	treeNode := &tree.Node{
+		Name:     node.Name,
+		IsFile:   node.Type == "file",
+		Children: make([]*tree.Node, 0),
+		Comment:  node.Comment,
+		Content:  node.Content,
+		Line:     node.Line,
+	}
+	if node.Hooks != nil {
+		treeNode.Hooks = tree.Hooks{Pre: node.Hooks.Pre, Post: node.Hooks.Post}
+	}
+
+	if node.Mode != "" {
+		mode, err := parseMode(node.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mode %q for %s: %w", node.Mode, node.Name, err)
+		}
+		treeNode.Mode = mode
 	}
 
 	for i := range node.Contents {
-		childNode := p.fileNodeToTreeNode(&node.Contents[i])
-		treeNode.children = append(treeNode.children, childNode)
+		childNode, err := fileNodeToTreeNode(ctx, &node.Contents[i], false)
+		if err != nil {
+			return nil, err
+		}
+		treeNode.Children = append(treeNode.Children, childNode)
 	}
 
-	return treeNode
+	return treeNode, nil
 }
 
-func (p *jsonParser) countTreeNodes(node *TreeNode) (directories int, files int) {
-	if node == nil {
-		return 0, 0
+// ParseStream reads `tree -J` output token by token, emitting each node as soon
+// as its name and type are known. Unlike Parse it needs type and name to come
+// before contents, which is the order both tree and seed write them in. The
+// report is checked once the whole tree has been emitted.
+func (p *jsonParser) ParseStream(ctx context.Context, r io.Reader, emit formats.EmitFunc) error {
+	s := &jsonStream{ctx: ctx, dec: json.NewDecoder(r), emit: emit}
+	if err := s.document(); err != nil {
+		return fmt.Errorf("failed to parse tree: %w", err)
 	}
+	return nil
+}
+
+type jsonStream struct {
+	ctx         context.Context
+	dec         *json.Decoder
+	emit        formats.EmitFunc
+	directories int
+	files       int
+}
 
-	if node.isFile {
-		files = 1
-	} else {
-		directories = 1
+func (s *jsonStream) document() error {
+	if err := s.delim('['); err != nil {
+		return err
+	}
+	if !s.dec.More() {
+		return fmt.Errorf("empty JSON array")
+	}
+	if err := s.node(""); err != nil {
+		return err
 	}
 
-	for _, child := range node.children {
-		d, f := p.countTreeNodes(child)
-		directories += d
-		files += f
+	// Verify report if present
+	if s.dec.More() {
+		var report Report
+		if err := s.dec.Decode(&report); err != nil {
+			return fmt.Errorf("failed to parse report: %w", err)
+		}
+		if s.directories != report.Directories || s.files != report.Files {
+			return fmt.Errorf("file system count mismatch - expected: %d directories and %d files, got: %d directories and %d files",
+				report.Directories, report.Files, s.directories, s.files)
+		}
 	}
 
-	return directories, files
+	// anything after the report is ignored, as it is by Parse
+	for s.dec.More() {
+		var skip json.RawMessage
+		if err := s.dec.Decode(&skip); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	return s.delim(']')
+}
+
+// node reads one object, emitting it before descending into its contents
+func (s *jsonStream) node(dir string) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.delim('{'); err != nil {
+		return err
+	}
+
+	var (
+		node    FileNode
+		emitted bool
+	)
+	emitNode := func() error {
+		if node.Type == "" {
+			return fmt.Errorf("missing type field")
+		}
+		if node.Name == "" {
+			return fmt.Errorf("missing name field")
+		}
+		treeNode, err := fileNodeToTreeNode(s.ctx, &node, dir == "")
+		if err != nil {
+			return err
+		}
+		if treeNode.IsFile {
+			s.files++
+		} else {
+			s.directories++
+		}
+		emitted = true
+		return s.emit(dir, treeNode)
+	}
+
+	for s.dec.More() {
+		token, err := s.dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		key, _ := token.(string)
+
+		var field *string
+		switch key {
+		case "type":
+			field = &node.Type
+		case "name":
+			field = &node.Name
+		case "mode":
+			field = &node.Mode
+		case "comment":
+			field = &node.Comment
+		case "content":
+			field = &node.Content
+		case "contents":
+			if emitted {
+				return fmt.Errorf("contents given twice for %s", node.Name)
+			}
+			if err := emitNode(); err != nil {
+				return fmt.Errorf("%w before contents", err)
+			}
+			if err := s.contents(joinDir(dir, node.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if field == nil {
+			var skip json.RawMessage
+			if err := s.dec.Decode(&skip); err != nil {
+				return fmt.Errorf("invalid node: %w", err)
+			}
+			continue
+		}
+		if emitted {
+			return fmt.Errorf("%s of %s must come before contents", key, node.Name)
+		}
+		if err := s.dec.Decode(field); err != nil {
+			return fmt.Errorf("invalid node: %w", err)
+		}
+	}
+
+	if !emitted {
+		if err := emitNode(); err != nil {
+			return err
+		}
+	}
+	return s.delim('}')
+}
+
+func (s *jsonStream) contents(dir string) error {
+	token, err := s.dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	// `"contents": null` is what an empty slice marshals to
+	if token == nil {
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("invalid node: contents must be an array")
+	}
+
+	for s.dec.More() {
+		if err := s.node(dir); err != nil {
+			return err
+		}
+	}
+	return s.delim(']')
+}
+
+func (s *jsonStream) delim(want json.Delim) error {
+	token, err := s.dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if token != want {
+		return fmt.Errorf("invalid JSON: expected %s, got %v", want, token)
+	}
+	return nil
 }
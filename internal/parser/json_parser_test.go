@@ -1,11 +1,12 @@
 package parser
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
-	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/planter"
 	logMock "github.com/jpwallace22/seed/pkg/logger/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -24,10 +25,7 @@ func (s *JsonTestSuite) SetupTest() {
 	s.Require().NoError(os.Chdir(s.tempDir))
 
 	s.logger = logMock.New()
-	testCtx := &ctx.SeedContext{
-		Logger: s.logger,
-	}
-	s.parser = NewJSONParser(testCtx)
+	s.parser = NewJSONParser()
 }
 
 func (s *JsonTestSuite) TearDownTestSuite() {
@@ -36,19 +34,19 @@ func (s *JsonTestSuite) TearDownTestSuite() {
 
 func (s *JsonTestSuite) TestEmptyInput() {
 	s.Run("empty input should error", func() {
-		err := s.parser.ParseTree("")
+		err := s.plant("")
 		s.Error(err, "Expected error for empty input")
 	})
 }
 
 func (s *JsonTestSuite) TestInvalidJSON() {
 	s.Run("invalid JSON should error", func() {
-		err := s.parser.ParseTree("not json")
+		err := s.plant("not json")
 		s.Error(err, "Expected error for invalid JSON")
 	})
 
 	s.Run("incomplete JSON should error", func() {
-		err := s.parser.ParseTree(`[{"type":"directory","name":"root"`)
+		err := s.plant(`[{"type":"directory","name":"root"`)
 		s.Error(err, "Expected error for incomplete JSON")
 	})
 }
@@ -68,7 +66,7 @@ func (s *JsonTestSuite) TestSimpleStructure() {
 	expectedDirs := []string{"root", "root/dir1", "root/dir2"}
 
 	s.Run("create directory structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -107,7 +105,7 @@ func (s *JsonTestSuite) TestComplexStructure() {
 	}
 
 	s.Run("create nested directory structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -146,7 +144,7 @@ func (s *JsonTestSuite) TestDeepNesting() {
 	}
 
 	s.Run("create deeply nested structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		deepFile := filepath.Join(s.tempDir, "root/level1/level2/level3/deep.txt")
@@ -187,7 +185,7 @@ func (s *JsonTestSuite) TestMultipleSiblings() {
 	}
 
 	s.Run("create structure with multiple siblings", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		for _, file := range []string{"file1.txt", "file2.txt", "file3.txt"} {
@@ -207,7 +205,7 @@ func (s *JsonTestSuite) TestReportValidation() {
 			]},
 			{"type":"report","directories":1,"files":2}
 		]`
-		err := s.parser.ParseTree(input)
+		err := s.plant(input)
 		s.Error(err, "Expected error for incorrect file count in report")
 	})
 
@@ -218,7 +216,7 @@ func (s *JsonTestSuite) TestReportValidation() {
 			]},
 			{"type":"report","directories":3,"files":0}
 		]`
-		err := s.parser.ParseTree(input)
+		err := s.plant(input)
 		s.Error(err, "Expected error for incorrect directory count in report")
 	})
 }
@@ -230,7 +228,7 @@ func (s *JsonTestSuite) TestMissingFields() {
 				{"type":"file","name":"file1.txt"}
 			]}
 		]`
-		err := s.parser.ParseTree(input)
+		err := s.plant(input)
 		s.Error(err, "Expected error for missing type field")
 	})
 
@@ -240,7 +238,7 @@ func (s *JsonTestSuite) TestMissingFields() {
 				{"type":"file","name":"file1.txt"}
 			]}
 		]`
-		err := s.parser.ParseTree(input)
+		err := s.plant(input)
 		s.Error(err, "Expected error for missing name field")
 	})
 }
@@ -265,7 +263,7 @@ func (s *JsonTestSuite) TestWithoutReport() {
 	}
 
 	s.Run("create structure without report section", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		// Verify specific files exist
@@ -274,6 +272,15 @@ func (s *JsonTestSuite) TestWithoutReport() {
 	})
 }
 
+func (s *JsonTestSuite) plant(input string) error {
+	root, err := s.parser.Parse(context.Background(), input)
+	if err != nil {
+		return err
+	}
+	_, err = planter.Plant(context.Background(), root, "", s.logger)
+	return err
+}
+
 func (s *JsonTestSuite) verifyStructure(expectedFiles, expectedDirs []string) {
 	var actualFiles, actualDirs []string
 
@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type jsonWriter struct {
+	indent int
+}
+
+func NewJSONWriter() Writer {
+	return &jsonWriter{indent: defaultIndent}
+}
+
+// NewIndentedJSONWriter indents nested nodes by width spaces
+func NewIndentedJSONWriter(width int) Writer {
+	return &jsonWriter{indent: width}
+}
+
+// renders the tree in the same shape as `tree -J`, including the trailing report
+func (w *jsonWriter) Write(out io.Writer, root *tree.Node) error {
+	dirs, files := root.Counts()
+	output := []interface{}{
+		treeNodeToFileNode(root),
+		Report{Type: "report", Directories: dirs, Files: files},
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", strings.Repeat(" ", w.indent))
+	return encoder.Encode(output)
+}
+
+func treeNodeToFileNode(node *tree.Node) FileNode {
+	fileNode := FileNode{
+		Type:    "directory",
+		Name:    node.Name,
+		Comment: node.Comment,
+		Content: node.Content,
+	}
+	if node.IsFile {
+		fileNode.Type = "file"
+	}
+	if node.Mode != 0 {
+		fileNode.Mode = formatMode(node.Mode)
+	}
+	if !node.Hooks.Empty() {
+		fileNode.Hooks = &FileHooks{Pre: node.Hooks.Pre, Post: node.Hooks.Post}
+	}
+
+	for _, child := range node.Children {
+		fileNode.Contents = append(fileNode.Contents, treeNodeToFileNode(child))
+	}
+
+	return fileNode
+}
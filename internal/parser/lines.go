@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// jsonLines records where each node of the first element of a JSON seed
+// starts. It gives up quietly on anything unexpected, since the document has
+// already been decoded and lines are only used for reporting.
+func jsonLines(src string, root *FileNode) {
+	l := &lineCounter{src: src, dec: json.NewDecoder(strings.NewReader(src))}
+	if tok, err := l.dec.Token(); err != nil || tok != json.Delim('[') {
+		return
+	}
+	l.node(root)
+}
+
+type lineCounter struct {
+	src string
+	dec *json.Decoder
+	// offset and line advance together, as offsets only grow
+	offset int
+	line   int
+}
+
+// lineAt is the line of the byte at offset
+func (l *lineCounter) lineAt(offset int) int {
+	if l.line == 0 {
+		l.line = 1
+	}
+	if offset > len(l.src) {
+		offset = len(l.src)
+	}
+	l.line += strings.Count(l.src[l.offset:offset], "\n")
+	l.offset = offset
+	return l.line
+}
+
+func (l *lineCounter) node(node *FileNode) bool {
+	tok, err := l.dec.Token()
+	if err != nil || tok != json.Delim('{') {
+		return false
+	}
+	// the decoder is just past the opening brace
+	node.Line = l.lineAt(int(l.dec.InputOffset()) - 1)
+
+	for l.dec.More() {
+		key, err := l.dec.Token()
+		if err != nil {
+			return false
+		}
+		if key != "contents" {
+			var skip json.RawMessage
+			if err := l.dec.Decode(&skip); err != nil {
+				return false
+			}
+			continue
+		}
+
+		if tok, err := l.dec.Token(); err != nil || tok != json.Delim('[') {
+			// contents may be null
+			continue
+		}
+		for i := 0; l.dec.More(); i++ {
+			if i >= len(node.Contents) || !l.node(&node.Contents[i]) {
+				return false
+			}
+		}
+		if _, err := l.dec.Token(); err != nil {
+			return false
+		}
+	}
+	_, err = l.dec.Token()
+	return err == nil
+}
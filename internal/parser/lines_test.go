@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lines(root *tree.Node) map[string]int {
+	lines := map[string]int{}
+	root.Visit(func(path string, node *tree.Node) bool {
+		lines[path] = node.Line
+		return true
+	})
+	return lines
+}
+
+func TestSourceLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser Parser
+		input  string
+		want   map[string]int
+	}{
+		{"tree", NewTreeParser(), "tree\napp\n├── src\n\n│   └── main.go\n└── README.md\n", map[string]int{"": 2, "src": 3, "src/main.go": 5, "README.md": 6}},
+		{"json", NewJSONParser(), `[
+  {"type": "directory", "name": "app", "hooks": {"post": ["true"]}, "contents": [
+    {"type": "directory", "name": "src", "contents": [
+
+      {"type": "file", "name": "main.go"}
+    ]},
+    {"type": "file", "name": "README.md"}
+  ]},
+  {"type": "report", "directories": 2, "files": 2}
+]`, map[string]int{"": 2, "src": 3, "src/main.go": 5, "README.md": 7}},
+		{"yaml", NewYAMLParser(), `name: app
+contents:
+  - name: src
+    contents:
+      # the entry point
+      - name: main.go
+  - name: README.md
+`, map[string]int{"": 1, "src": 3, "src/main.go": 6, "README.md": 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := tt.parser.Parse(context.Background(), tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lines(root))
+		})
+	}
+}
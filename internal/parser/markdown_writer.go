@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type markdownWriter struct {
+	indent int
+}
+
+func NewMarkdownWriter() Writer {
+	return &markdownWriter{indent: defaultIndent}
+}
+
+// NewIndentedMarkdownWriter nests list items by width spaces
+func NewIndentedMarkdownWriter(width int) Writer {
+	return &markdownWriter{indent: width}
+}
+
+// renders the tree as a nested list, with directories marked by a trailing slash
+func (w *markdownWriter) Write(out io.Writer, root *tree.Node) error {
+	buf := bufio.NewWriter(out)
+	w.writeNode(buf, root, 0)
+	return buf.Flush()
+}
+
+func (w *markdownWriter) writeNode(buf *bufio.Writer, node *tree.Node, depth int) {
+	line := strings.Repeat(" ", w.indent*depth) + "- " + node.Name
+	if !node.IsFile {
+		line += "/"
+	}
+	if node.Comment != "" {
+		line += " — " + node.Comment
+	}
+	buf.WriteString(line + "\n")
+
+	for _, child := range node.Children {
+		w.writeNode(buf, child, depth+1)
+	}
+}
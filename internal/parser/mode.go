@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prot renders permissions the way `tree -p` and `ls -l` do, e.g. drwxr-xr-x
+func prot(mode os.FileMode, isDir bool) string {
+	var b strings.Builder
+	if isDir {
+		b.WriteByte('d')
+	} else {
+		b.WriteByte('-')
+	}
+
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		if mode&(1<<uint(8-i)) != 0 {
+			b.WriteByte(rwx[i])
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
+
+// parseProt reads a leading `[drwxr-xr-x]` block as printed by `tree -p`
+func parseProt(entry string) (mode os.FileMode, isDir bool, ok bool) {
+	if len(entry) < 12 || entry[0] != '[' || entry[11] != ']' {
+		return 0, false, false
+	}
+
+	switch entry[1] {
+	case 'd':
+		isDir = true
+	case '-':
+	default:
+		return 0, false, false
+	}
+
+	const rwx = "rwxrwxrwx"
+	for i := 0; i < 9; i++ {
+		switch entry[2+i] {
+		case rwx[i]:
+			mode |= 1 << uint(8-i)
+		case '-':
+		default:
+			return 0, false, false
+		}
+	}
+	return mode, isDir, true
+}
+
+// parseMode reads an octal mode such as "0644" or "755"
+func parseMode(value string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	return os.FileMode(mode).Perm(), nil
+}
+
+func formatMode(mode os.FileMode) string {
+	return "0" + strconv.FormatUint(uint64(mode.Perm()), 8)
+}
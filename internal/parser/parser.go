@@ -1,103 +1,78 @@
 package parser
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-
-	"github.com/jpwallace22/seed/cmd/flags"
-	"github.com/jpwallace22/seed/internal/ctx"
-	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/formats"
 )
 
-type Parser interface {
-	ParseTree(string) error
-}
+// Parser builds a tree from its text representation without touching the filesystem.
+type Parser = formats.Parser
 
-type TreeNode struct {
-	name     string
-	children []*TreeNode
-	isFile   bool
-	depth    int
-}
+// Writer renders a parsed tree back into one of the supported formats.
+type Writer = formats.Writer
 
 type Option func(*config)
 
 type config struct {
-	format flags.Format
+	format string
+	ascii  bool
+	indent int
 }
 
-func NewParser(ctx *ctx.SeedContext, opts ...Option) (Parser, error) {
-	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+func NewParser(opts ...Option) (Parser, error) {
+	cfg := &config{
+		format: formatTree,
 	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return formats.NewParser(cfg.format)
+}
 
+func NewWriter(opts ...Option) (Writer, error) {
 	cfg := &config{
-		format: flags.Formats.Tree,
+		format: formatTree,
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	switch cfg.format {
-	case flags.Formats.JSON:
-		return NewJSONParser(ctx), nil
-	case flags.Formats.Tree:
-		return NewTreeParser(ctx), nil
-	default:
-		return nil, fmt.Errorf("unsupported parser format: %s", cfg.format)
-	}
+	return formats.NewWriter(cfg.format, formats.WriteOptions{ASCII: cfg.ascii, Indent: cfg.indent})
 }
 
-func WithFormat(format flags.Format) Option {
+func WithFormat(format string) Option {
 	return func(c *config) {
 		c.format = format
 	}
 }
 
-// This should move to a new module for filesystems, its breaking single job pattern
-func createFileSystem(node *TreeNode, parentPath string, logger logger.Logger) error {
-	if node == nil {
-		return nil
+// DetectFormat picks an input format from the file extension, falling back when it is not recognised.
+func DetectFormat(path string, fallback string) string {
+	if f, ok := formats.ByExtension(path); ok && f.CanParse() {
+		return f.Name
 	}
-	permissions := os.FileMode(0755)
+	return fallback
+}
 
-	currentPath := parentPath
-	if node.name != "." {
-		currentPath = filepath.Join(parentPath, node.name)
+// SniffFormat picks a format from the content, falling back when no format claims it.
+func SniffFormat(data []byte, fallback string) string {
+	if f, ok := formats.Sniff(data); ok {
+		return f.Name
 	}
+	return fallback
+}
 
-	// create current node unless it's the "." root
-	if node.name != "." {
-		if node.isFile {
-			// ensure parent directory exists
-			parentDir := filepath.Dir(currentPath)
-			if err := os.MkdirAll(parentDir, permissions); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", parentDir, err)
-			}
-
-			// create file
-			f, err := os.Create(currentPath)
-			if err != nil {
-				return fmt.Errorf("failed to create file %s: %w", currentPath, err)
-			}
-
-			f.Close()
-			logger.Info("Planted file: " + currentPath)
-		} else {
-			if err := os.MkdirAll(currentPath, permissions); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", currentPath, err)
-			}
-			logger.Info("Planted directory: " + currentPath)
-		}
+// WithIndent sets the indentation width of the JSON, YAML and markdown
+// writers. The tree format always indents like `tree` does.
+func WithIndent(width int) Option {
+	return func(c *config) {
+		c.indent = width
 	}
+}
 
-	// loop through children with the correct parent path
-	for _, child := range node.children {
-		if err := createFileSystem(child, currentPath, logger); err != nil {
-			return err
-		}
+// WithASCII makes the tree writer use `tree --charset=ascii` connectors.
+func WithASCII(ascii bool) Option {
+	return func(c *config) {
+		c.ascii = ascii
 	}
-
-	return nil
 }
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type pathsWriter struct{}
+
+func NewPathsWriter() Writer {
+	return &pathsWriter{}
+}
+
+// renders one path per line, root included, with directories marked by a trailing slash
+func (w *pathsWriter) Write(out io.Writer, root *tree.Node) error {
+	buf := bufio.NewWriter(out)
+	w.writeNode(buf, root, "")
+	return buf.Flush()
+}
+
+func (w *pathsWriter) writeNode(buf *bufio.Writer, node *tree.Node, parent string) {
+	path := node.Name
+	if parent != "" {
+		path = parent + "/" + node.Name
+	}
+
+	if node.IsFile {
+		buf.WriteString(path + "\n")
+		return
+	}
+	buf.WriteString(path + "/\n")
+
+	for _, child := range node.Children {
+		w.writeNode(buf, child, path)
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+)
+
+const (
+	formatTree     = "tree"
+	formatJSON     = "json"
+	formatYAML     = "yaml"
+	formatMarkdown = "markdown"
+	formatPaths    = "paths"
+)
+
+// The built-in formats register in the order they are listed in --help
+func init() {
+	formats.Register(formats.Format{
+		Name:       formatTree,
+		Extensions: []string{".tree", ".seed", ".txt"},
+		NewParser:  NewTreeParser,
+		NewWriter: func(opts formats.WriteOptions) formats.Writer {
+			if opts.ASCII {
+				return NewASCIITreeWriter()
+			}
+			return NewTreeWriter()
+		},
+	})
+	formats.Register(formats.Format{
+		Name:       formatJSON,
+		Extensions: []string{".json"},
+		Sniff:      sniffJSON,
+		NewParser:  NewJSONParser,
+		NewWriter:  func(opts formats.WriteOptions) formats.Writer { return NewIndentedJSONWriter(indentWidth(opts)) },
+	})
+	formats.Register(formats.Format{
+		Name:       formatYAML,
+		Extensions: []string{".yaml", ".yml"},
+		Sniff:      sniffYAML,
+		NewParser:  NewYAMLParser,
+		NewWriter:  func(opts formats.WriteOptions) formats.Writer { return NewIndentedYAMLWriter(indentWidth(opts)) },
+	})
+	formats.Register(formats.Format{
+		Name:       formatMarkdown,
+		Extensions: []string{".md"},
+		NewWriter:  func(opts formats.WriteOptions) formats.Writer { return NewIndentedMarkdownWriter(indentWidth(opts)) },
+	})
+	formats.Register(formats.Format{
+		Name:      formatPaths,
+		NewWriter: func(formats.WriteOptions) formats.Writer { return NewPathsWriter() },
+	})
+
+	formats.RegisterFinder(findPlugin)
+}
+
+// defaultIndent is the indentation width of the nested formats
+const defaultIndent = 2
+
+func indentWidth(opts formats.WriteOptions) int {
+	if opts.Indent > 0 {
+		return opts.Indent
+	}
+	return defaultIndent
+}
+
+// sniffJSON accepts the array of objects printed by `tree -J`. Only the start
+// is looked at, so it also works on the first bytes of a stream. A tree whose
+// root line starts with `[drwxr-xr-x]` is not mistaken for it.
+func sniffJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if !bytes.HasPrefix(trimmed, []byte("[")) {
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(trimmed[1:]), []byte("{"))
+}
+
+var yamlKey = regexp.MustCompile(`^(name|type|mode|comment|content|contents|hooks)\s*:`)
+
+// sniffYAML looks at the first meaningful line. YAML seeds decode with known
+// fields only, so it has to be a document marker or one of the node keys.
+func sniffYAML(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		return bytes.Equal(line, []byte("---")) || yamlKey.Match(line)
+	}
+	return false
+}
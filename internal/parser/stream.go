@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// joinDir is the dir a streaming parser emits for the children of name
+func joinDir(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return path.Join(dir, name)
+}
+
+// collector builds the whole tree from emitted nodes, so a streaming parser
+// can also implement Parse
+type collector struct {
+	root *tree.Node
+	// ancestors of the last emitted node, the last emitted node included
+	stack []collected
+}
+
+type collected struct {
+	dir  string
+	node *tree.Node
+}
+
+func (c *collector) emit(dir string, node *tree.Node) error {
+	if dir == "" {
+		c.root = node
+		c.stack = []collected{{dir: joinDir("", node.Name), node: node}}
+		return nil
+	}
+
+	// nodes arrive depth-first, so the parent is always one of the ancestors
+	for len(c.stack) > 0 && c.stack[len(c.stack)-1].dir != dir {
+		c.stack = c.stack[:len(c.stack)-1]
+	}
+	if len(c.stack) == 0 {
+		return fmt.Errorf("no parent %s for %s", dir, node.Name)
+	}
+
+	parent := c.stack[len(c.stack)-1].node
+	parent.Children = append(parent.Children, node)
+	c.stack = append(c.stack, collected{dir: joinDir(dir, node.Name), node: node})
+	return nil
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type emitted struct {
+	dir    string
+	name   string
+	isFile bool
+}
+
+func collectStream(t *testing.T, p formats.Parser, input string) ([]emitted, error) {
+	t.Helper()
+	streaming, ok := p.(formats.StreamParser)
+	require.True(t, ok, "parser should stream")
+
+	var got []emitted
+	err := streaming.ParseStream(context.Background(), strings.NewReader(input), func(dir string, node *tree.Node) error {
+		assert.Empty(t, node.Children, "emitted nodes have no children")
+		got = append(got, emitted{dir: dir, name: node.Name, isFile: node.IsFile})
+		return nil
+	})
+	return got, err
+}
+
+func TestTreeParseStream(t *testing.T) {
+	got, err := collectStream(t, NewTreeParser(), `tree
+proj
+├── Makefile.d
+│   └── rules
+├── src/
+└── README.md
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []emitted{
+		{dir: "", name: "proj"},
+		{dir: "proj", name: "Makefile.d"},
+		{dir: "proj/Makefile.d", name: "rules", isFile: false},
+		{dir: "proj", name: "src"},
+		{dir: "proj", name: "README.md", isFile: true},
+	}, got)
+}
+
+func TestTreeParseStreamErrors(t *testing.T) {
+	_, err := collectStream(t, NewTreeParser(), "proj\n│   │   └── deep\n")
+	assert.ErrorContains(t, err, "missing parent at depth 2")
+
+	_, err = collectStream(t, NewTreeParser(), "\n\n")
+	assert.ErrorContains(t, err, "a root is required")
+
+	_, err = collectStream(t, NewTreeParser(), "proj\n└── "+strings.Repeat("x", maxLineLength+1)+"\n")
+	assert.ErrorContains(t, err, "unable to read the tree")
+
+	_, err = collectStream(t, NewTreeParser(), "proj\n├── ../../escaped.txt\n")
+	assert.ErrorContains(t, err, "line 2: \"../../escaped.txt\" cannot contain a path separator")
+
+	_, err = collectStream(t, NewTreeParser(), "proj\n└── ..\n    └── escaped.txt\n")
+	assert.ErrorContains(t, err, "line 2: \"..\" is not a valid name")
+}
+
+// lazyTree writes an endless-looking tree one line at a time and records how far it got
+type lazyTree struct {
+	lines   int
+	written int
+	buf     bytes.Buffer
+}
+
+func (l *lazyTree) Read(p []byte) (int, error) {
+	if l.buf.Len() == 0 {
+		if l.written == l.lines {
+			return 0, io.EOF
+		}
+		if l.written == 0 {
+			l.buf.WriteString("root\n")
+		} else {
+			fmt.Fprintf(&l.buf, "├── file%d.txt\n", l.written)
+		}
+		l.written++
+	}
+	return l.buf.Read(p)
+}
+
+func TestTreeParseStreamEmitsEarly(t *testing.T) {
+	input := &lazyTree{lines: 100000}
+	var writtenAtThird int
+	count := 0
+
+	err := NewTreeParser().(formats.StreamParser).ParseStream(context.Background(), input, func(dir string, node *tree.Node) error {
+		count++
+		if count == 3 {
+			writtenAtThird = input.written
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 100000, count)
+	assert.Less(t, writtenAtThird, 10000, "nodes should be emitted long before the input ends")
+}
+
+func TestJSONParseStream(t *testing.T) {
+	var out bytes.Buffer
+	root := parseWriterInput(t)
+	require.NoError(t, NewJSONWriter().Write(&out, root))
+
+	got, err := collectStream(t, NewJSONParser(), out.String())
+	require.NoError(t, err)
+	assert.Equal(t, []emitted{
+		{dir: "", name: "proj"},
+		{dir: "proj", name: ".github"},
+		{dir: "proj/.github", name: "workflows"},
+		{dir: "proj", name: "run.sh", isFile: true},
+		{dir: "proj", name: "src"},
+		{dir: "proj/src", name: "main.go", isFile: true},
+	}, got)
+
+	// building the tree from the stream gives the same result as Parse
+	var c collector
+	err = NewJSONParser().(formats.StreamParser).ParseStream(context.Background(), strings.NewReader(out.String()), c.emit)
+	require.NoError(t, err)
+	assert.True(t, root.Equal(c.root))
+}
+
+func TestJSONParseStreamErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "contents first", input: `[{"contents":[],"type":"directory","name":"p"}]`, err: "missing type field before contents"},
+		{name: "name after contents", input: `[{"type":"directory","contents":[],"name":"p"}]`, err: "missing name field before contents"},
+		{name: "missing name", input: `[{"type":"file"}]`, err: "missing name field"},
+		{name: "report mismatch", input: `[{"type":"directory","name":"p"},{"type":"report","directories":2,"files":0}]`, err: "count mismatch"},
+		{name: "not an array", input: `{"type":"directory","name":"p"}`, err: "invalid JSON"},
+		{name: "empty", input: `[]`, err: "empty JSON array"},
+		{name: "separator", input: `[{"type":"directory","name":"p","contents":[{"type":"file","name":"../x"}]}]`, err: "cannot contain a path separator"},
+		{name: "dot dot", input: `[{"type":"directory","name":"p","contents":[{"type":"directory","name":".."}]}]`, err: "not a valid name"},
+		{name: "truncated", input: `[{"type":"directory","name":"p","contents":[`, err: "invalid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := collectStream(t, NewJSONParser(), tt.input)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestJSONParseStreamNullContents(t *testing.T) {
+	got, err := collectStream(t, NewJSONParser(), `[{"type":"directory","name":"p","contents":null,"extra":{"a":1}}]`)
+	require.NoError(t, err)
+	assert.Equal(t, []emitted{{dir: "", name: "p"}}, got)
+}
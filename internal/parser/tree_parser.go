@@ -1,135 +1,204 @@
 package parser
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/tree"
 )
 
-type stringParser struct {
-	ctx *ctx.SeedContext
-}
+// maxLineLength bounds a single line of a streamed tree, which is what keeps memory bounded
+const maxLineLength = 1024 * 1024
 
-func NewTreeParser(ctx *ctx.SeedContext) Parser {
-	return &stringParser{
-		ctx: ctx,
-	}
-}
+type stringParser struct{}
 
-// converts a text representation of a directory tree into actual directories and files
-func (p *stringParser) ParseTree(tree string) error {
-	lines := strings.Split(strings.TrimSpace(tree), "\n")
-	if len(lines) == 0 {
-		return fmt.Errorf("no tree provided")
-	}
+func NewTreeParser() Parser {
+	return &stringParser{}
+}
 
-	if strings.TrimSpace(lines[0]) == "tree" {
-		lines = lines[1:]
+// converts a text representation of a directory tree into a tree.Node
+func (p *stringParser) Parse(ctx context.Context, input string) (*tree.Node, error) {
+	var c collector
+	if err := p.ParseStream(ctx, strings.NewReader(input), c.emit); err != nil {
+		return nil, err
 	}
+	return c.root, nil
+}
 
-	root, err := p.buildTree(lines)
-	if err != nil {
+// ParseStream reads the tree line by line. A node is emitted once the next
+// line has been read, since only then is it known whether it has children.
+func (p *stringParser) ParseStream(ctx context.Context, r io.Reader, emit formats.EmitFunc) error {
+	if err := p.stream(ctx, r, emit); err != nil {
 		return fmt.Errorf("failed to parse tree: %w", err)
 	}
-
-	err = createFileSystem(root, "", p.ctx.Logger)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// converts the string lines into a tree structure
-func (p *stringParser) buildTree(lines []string) (*TreeNode, error) {
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("no lines to parse")
-	}
-
-	rootName := strings.TrimSpace(lines[0])
-	if rootName == "" {
-		return nil, fmt.Errorf("a root is required")
-	}
-
-	root := &TreeNode{
-		name:     rootName,
-		isFile:   strings.Contains(rootName, ".") && rootName != ".",
-		children: make([]*TreeNode, 0),
-		depth:    0,
+func (p *stringParser) stream(ctx context.Context, r io.Reader, emit formats.EmitFunc) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	var (
+		// dirs holds the path of the last node at each depth, up to the pending one
+		dirs []string
+		// pending is the last node read, waiting for the next line to settle its type
+		pending      *tree.Node
+		pendingDepth int
+		pendingDir   string
+	)
+
+	flush := func() error {
+		if err := emit(pendingDir, pending); err != nil {
+			return err
+		}
+		dirs = append(dirs[:pendingDepth], joinDir(pendingDir, pending.Name))
+		return nil
 	}
 
-	// Keep track of last nodes at each depth level
-	lastNodes := make(map[int]*TreeNode)
-	lastNodes[0] = root
+	header := true
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	for i := 1; i < len(lines); i++ {
 		// Need to normalize the line by changing all spaces with ASCII
-		line := strings.ReplaceAll(lines[i], "\u00a0", " ")
-
+		line := strings.ReplaceAll(scanner.Text(), "\u00a0", " ")
 		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
+		if pending == nil {
+			// `tree` output pasted along with the command that produced it
+			if header && strings.TrimSpace(line) == "tree" {
+				header = false
+				continue
+			}
+			pending = p.parseEntry(line)
+			if pending.Name == "" {
+				return fmt.Errorf("a root is required")
+			}
+			if err := tree.CheckName(pending.Name, true); err != nil {
+				return fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			pending.Line = lineNo
+			continue
+		}
+
 		// Build the node
-		depth := p.getDepth(line)
-		name := p.extractName(line)
-		if name == "" {
+		depth, rest := p.splitLine(line)
+		node := p.parseEntry(rest)
+		if node.Name == "" {
 			continue
 		}
+		if err := tree.CheckName(node.Name, false); err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		node.Line = lineNo
 
-		node := &TreeNode{
-			name:     name,
-			isFile:   strings.Contains(name, "."),
-			children: make([]*TreeNode, 0),
-			depth:    depth,
+		// anything with children is a directory, whatever its name looks like
+		parentDepth := depth - 1
+		if parentDepth == pendingDepth {
+			pending.IsFile = false
+		}
+		if err := flush(); err != nil {
+			return err
 		}
 
 		// Assign the node to a parent
-		parentDepth := depth - 1
-		parent := lastNodes[parentDepth]
-		if parent == nil {
-			return nil, fmt.Errorf("invalid tree structure: missing parent at depth %d for node %s", parentDepth, name)
+		if parentDepth < 0 || parentDepth >= len(dirs) {
+			return fmt.Errorf("invalid tree structure: missing parent at depth %d for node %s", parentDepth, node.Name)
 		}
+		pending, pendingDepth, pendingDir = node, depth, dirs[parentDepth]
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read the tree: %w", err)
+	}
 
-		parent.children = append(parent.children, node)
-		lastNodes[depth] = node
+	if pending == nil {
+		return fmt.Errorf("a root is required")
 	}
+	return flush()
+}
 
-	return root, nil
+// indentation units, in both the GNU glyphs and the `tree --charset=ascii` ones
+var treeIndents = []string{
+	"│   ", "    ", "├── ", "└── ",
+	"|   ", "|-- ", "`-- ",
 }
 
-func (p *stringParser) getDepth(line string) int {
+// splitLine consumes the indentation and connectors, returning the depth and the entry that follows
+func (p *stringParser) splitLine(line string) (int, string) {
 	depth := 0
-	for i := 0; i < len(line); {
-		if strings.HasPrefix(line[i:], "│   ") {
-			depth++
-			i += 4
-		} else if strings.HasPrefix(line[i:], "    ") {
-			depth++
-			i += 4
-		} else if strings.HasPrefix(line[i:], "├── ") {
-			depth++
-			i += 4
-		} else if strings.HasPrefix(line[i:], "└── ") {
-			depth++
-			i += 4
-		} else {
-			i++
+	i := 0
+	for i < len(line) {
+		matched := false
+		for _, indent := range treeIndents {
+			if strings.HasPrefix(line[i:], indent) {
+				depth++
+				i += len(indent)
+				matched = true
+				break
+			}
 		}
+		if matched {
+			continue
+		}
+
+		// stray padding and bars are tolerated until the name starts
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if !unicode.IsSpace(r) && !strings.ContainsRune("│├└─|`", r) {
+			break
+		}
+		i += size
 	}
-	return depth
+	return depth, line[i:]
 }
 
-func (p *stringParser) extractName(line string) string {
-	line = strings.TrimSpace(line)
-	unwantedChars := []string{
-		"├── ", "└── ", "/", "\\",
-		"│   ", "    ",
-		"│", "└", "├", "─",
+// fileTag marks a file without giving it a mode, for names such as Makefile
+// that would otherwise be read as directories
+const fileTag = "[-]"
+
+// parseEntry reads a single `[prot]  name/` entry
+func (p *stringParser) parseEntry(entry string) *tree.Node {
+	node := &tree.Node{
+		Children: make([]*tree.Node, 0),
 	}
-	for _, char := range unwantedChars {
-		line = strings.ReplaceAll(line, char, "")
+
+	entry = strings.TrimSpace(entry)
+	typed := false
+	if rest, ok := strings.CutPrefix(entry, fileTag); ok {
+		node.IsFile = true
+		typed = true
+		entry = strings.TrimSpace(rest)
+	} else if mode, isDir, ok := parseProt(entry); ok {
+		node.Mode = mode
+		node.IsFile = !isDir
+		typed = true
+		entry = strings.TrimSpace(entry[len(prot(mode, isDir))+2:])
+	}
+
+	name := strings.TrimRight(entry, "/\\")
+	if name != entry {
+		node.IsFile = false
+		typed = true
 	}
-	return strings.TrimSpace(line)
+	node.Name = strings.TrimSpace(name)
+
+	if !typed {
+		node.IsFile = looksLikeFile(node.Name)
+	}
+	return node
+}
+
+// without any other hint, names with an extension are taken to be files
+func looksLikeFile(name string) bool {
+	return strings.Contains(name, ".") && name != "." && name != ".."
 }
@@ -1,11 +1,12 @@
 package parser
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
-	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/planter"
 	logMock "github.com/jpwallace22/seed/pkg/logger/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -30,10 +31,7 @@ func (s *ParserTestSuite) SetupTest() {
 	s.Require().NoError(os.Chdir(s.tempDir))
 
 	s.logger = logMock.New()
-	testCtx := &ctx.SeedContext{
-		Logger: s.logger,
-	}
-	s.parser = NewTreeParser(testCtx)
+	s.parser = NewTreeParser()
 }
 
 func (s *ParserTestSuite) TearDownTestSuite() {
@@ -42,7 +40,7 @@ func (s *ParserTestSuite) TearDownTestSuite() {
 
 func (s *ParserTestSuite) TestEmptyInput() {
 	s.Run("empty input should error", func() {
-		err := s.parser.ParseTree("")
+		err := s.plant("")
 		s.Error(err, "Expected error for empty input")
 	})
 }
@@ -60,7 +58,7 @@ root
 	expectedDirs := []string{"root"}
 
 	s.Run("create tree with prefix", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -75,7 +73,7 @@ func (s *ParserTestSuite) TestSimpleStructure() {
 	expectedDirs := []string{"root", "root/dir1", "root/dir2"}
 
 	s.Run("create directory structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -90,7 +88,7 @@ func (s *ParserTestSuite) TestStripsTrailingSlashes() {
 	expectedDirs := []string{"root", "root/dir1", "root/dir2"}
 
 	s.Run("create directory structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -121,7 +119,7 @@ func (s *ParserTestSuite) TestComplexStructure() {
 	}
 
 	s.Run("create nested directory structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -153,7 +151,7 @@ func (s *ParserTestSuite) TestDotRoot() {
 	}
 
 	s.Run("create structure with dot root", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		// Additional checks for correct nesting
@@ -186,7 +184,7 @@ func (s *ParserTestSuite) TestRealWorldExample() {
 	}
 
 	s.Run("create real world structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 	})
 }
@@ -217,7 +215,7 @@ func (s *ParserTestSuite) TestDeepNesting() {
 	}
 
 	s.Run("create deeply nested structure", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		// Verify specific deep nesting
@@ -255,7 +253,7 @@ func (s *ParserTestSuite) TestMultipleSiblings() {
 	}
 
 	s.Run("create structure with multiple siblings", func() {
-		s.Require().NoError(s.parser.ParseTree(input))
+		s.Require().NoError(s.plant(input))
 		s.verifyStructure(expectedFiles, expectedDirs)
 
 		// Verify sibling files are in correct directories
@@ -268,6 +266,15 @@ func (s *ParserTestSuite) TestMultipleSiblings() {
 	})
 }
 
+func (s *ParserTestSuite) plant(input string) error {
+	root, err := s.parser.Parse(context.Background(), input)
+	if err != nil {
+		return err
+	}
+	_, err = planter.Plant(context.Background(), root, "", s.logger)
+	return err
+}
+
 func (s *ParserTestSuite) verifyStructure(expectedFiles, expectedDirs []string) {
 	var actualFiles, actualDirs []string
 
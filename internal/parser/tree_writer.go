@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type treeGlyphs struct {
+	branch, last, pipe, space string
+}
+
+type treeWriter struct {
+	glyphs treeGlyphs
+}
+
+func NewTreeWriter() Writer {
+	return &treeWriter{
+		glyphs: treeGlyphs{branch: "├── ", last: "└── ", pipe: "│   ", space: "    "},
+	}
+}
+
+// NewASCIITreeWriter writes the same layout as `tree --charset=ascii`.
+func NewASCIITreeWriter() Writer {
+	return &treeWriter{
+		glyphs: treeGlyphs{branch: "|-- ", last: "`-- ", pipe: "|   ", space: "    "},
+	}
+}
+
+// renders the tree in the same format the tree command prints, which is also
+// the format the tree parser reads
+func (w *treeWriter) Write(out io.Writer, root *tree.Node) error {
+	buf := bufio.NewWriter(out)
+	buf.WriteString(w.entry(root) + "\n")
+	w.writeChildren(buf, root, "")
+	return buf.Flush()
+}
+
+func (w *treeWriter) writeChildren(buf *bufio.Writer, node *tree.Node, prefix string) {
+	for i, child := range node.Children {
+		connector, indent := w.glyphs.branch, w.glyphs.pipe
+		if i == len(node.Children)-1 {
+			connector, indent = w.glyphs.last, w.glyphs.space
+		}
+
+		buf.WriteString(prefix + connector + w.entry(child) + "\n")
+		w.writeChildren(buf, child, prefix+indent)
+	}
+}
+
+// entry renders a node as `[prot]  name`, the inverse of parseEntry. The
+// format has no room for comments, so they are left out.
+func (w *treeWriter) entry(node *tree.Node) string {
+	switch {
+	case node.Mode != 0:
+		return "[" + prot(node.Mode, !node.IsFile) + "]  " + node.Name
+	case !node.IsFile && looksLikeFile(node.Name):
+		// otherwise the parser would read it back as a file
+		return node.Name + "/"
+	case node.IsFile && !looksLikeFile(node.Name):
+		// files such as Makefile have nothing else to tell them from directories
+		return fileTag + "  " + node.Name
+	}
+	return node.Name
+}
@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const writerInput = `proj
+├── .github/
+│   └── workflows
+├── [-rwxr-xr-x]  run.sh
+└── src
+    └── main.go
+`
+
+// parseWriterInput parses writerInput and adds the comments the tree format
+// cannot hold
+func parseWriterInput(t *testing.T) *tree.Node {
+	root, err := NewTreeParser().Parse(context.Background(), writerInput)
+	require.NoError(t, err)
+	root.Comment = "the project"
+	root.Find("src/main.go").Comment = "entry"
+	return root
+}
+
+func TestWriters(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "tree",
+			opts:     []Option{WithFormat(formatTree)},
+			expected: writerInput,
+		},
+		{
+			name: "ascii tree",
+			opts: []Option{WithFormat(formatTree), WithASCII(true)},
+			expected: "proj\n" +
+				"|-- .github/\n" +
+				"|   `-- workflows\n" +
+				"|-- [-rwxr-xr-x]  run.sh\n" +
+				"`-- src\n" +
+				"    `-- main.go\n",
+		},
+		{
+			name: "markdown",
+			opts: []Option{WithFormat(formatMarkdown)},
+			expected: `- proj/ — the project
+  - .github/
+    - workflows/
+  - run.sh
+  - src/
+    - main.go — entry
+`,
+		},
+		{
+			name: "paths",
+			opts: []Option{WithFormat(formatPaths)},
+			expected: `proj/
+proj/.github/
+proj/.github/workflows/
+proj/run.sh
+proj/src/
+proj/src/main.go
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, err := NewWriter(tt.opts...)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, writer.Write(&out, parseWriterInput(t)))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestWriterRoundTrips(t *testing.T) {
+	tests := []struct {
+		name     string
+		writer   Writer
+		parser   Parser
+		comments bool
+	}{
+		{"tree", NewTreeWriter(), NewTreeParser(), false},
+		{"ascii tree", NewASCIITreeWriter(), NewTreeParser(), false},
+		{"json", NewJSONWriter(), NewJSONParser(), true},
+		{"yaml", NewYAMLWriter(), NewYAMLParser(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := parseWriterInput(t)
+
+			var out bytes.Buffer
+			require.NoError(t, tt.writer.Write(&out, root))
+
+			parsed, err := tt.parser.Parse(context.Background(), out.String())
+			require.NoError(t, err)
+			assert.Equal(t, root.Paths(), parsed.Paths())
+			assert.Equal(t, tt.comments, parsed.Equal(root), "comments survive where the format has room for them")
+
+			var again bytes.Buffer
+			require.NoError(t, NewTreeWriter().Write(&again, parsed))
+			assert.Equal(t, writerInput, again.String(), "modes should survive")
+		})
+	}
+}
+
+func TestUnsupportedWriter(t *testing.T) {
+	_, err := NewWriter(WithFormat("svg"))
+	assert.Error(t, err)
+}
+
+func TestYAMLParser(t *testing.T) {
+	input := `name: proj
+contents:
+  - name: src
+    contents:
+      - name: main.go
+        mode: "0600"
+        content: |
+          package main
+  - name: README.md
+  - name: bin
+`
+	root, err := NewYAMLParser().Parse(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"src":         false,
+		"src/main.go": true,
+		"README.md":   true,
+		"bin":         false,
+	}, root.Paths())
+
+	main := root.Children[0].Children[0]
+	assert.Equal(t, "package main\n", main.Content)
+	assert.Equal(t, "0600", formatMode(main.Mode))
+
+	_, err = NewYAMLParser().Parse(context.Background(), "contents: []")
+	assert.Error(t, err, "missing name should error")
+
+	_, err = NewYAMLParser().Parse(context.Background(), "name: x\ntype: socket")
+	assert.Error(t, err, "unknown type should error")
+
+	_, err = NewYAMLParser().Parse(context.Background(), "name: x\ncontents:\n  - name: ../../escaped.txt\n")
+	assert.ErrorContains(t, err, "cannot contain a path separator")
+}
+
+func TestSniffFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "tree -J output", input: `[{"type":"directory","name":"p"}]`, want: formatJSON},
+		{name: "yaml seed", input: "# layout\nname: p\ncontents: []\n", want: formatYAML},
+		{name: "yaml document marker", input: "---\nname: p\n", want: formatYAML},
+		{name: "yaml seed with hooks first", input: "hooks:\n  post: [git init]\nname: p\n", want: formatYAML},
+		{name: "tree", input: "p/\n└── a.go\n", want: formatTree},
+		{name: "verify rule is not json", input: "{src,lib}/\n", want: formatTree},
+		{name: "name with colon is not yaml", input: "notes: draft.md\n", want: formatTree},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SniffFormat([]byte(tt.input), formatTree))
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, formatYAML, DetectFormat("layout.YML", formatTree))
+	assert.Equal(t, formatTree, DetectFormat("layout.seed", formatJSON))
+	assert.Equal(t, formatTree, DetectFormat("README.md", formatTree), "output only formats are not detected")
+}
+
+func TestHooksRoundTrip(t *testing.T) {
+	input := `name: proj
+hooks:
+  pre: [git init]
+  post: ["go mod init {{.module}}"]
+contents:
+  - name: main.go
+    hooks:
+      post: [gofmt -w main.go]
+`
+	root, err := NewYAMLParser().Parse(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, tree.Hooks{Pre: []string{"git init"}, Post: []string{"go mod init {{.module}}"}}, root.Hooks)
+	assert.Equal(t, []string{"gofmt -w main.go"}, root.Children[0].Hooks.Post)
+
+	for _, w := range []struct {
+		writer Writer
+		parser Parser
+	}{
+		{NewJSONWriter(), NewJSONParser()},
+		{NewYAMLWriter(), NewYAMLParser()},
+	} {
+		var out bytes.Buffer
+		require.NoError(t, w.writer.Write(&out, root))
+		parsed, err := w.parser.Parse(context.Background(), out.String())
+		require.NoError(t, err)
+		assert.True(t, root.Equal(parsed), out.String())
+	}
+}
+
+func TestExtensionlessFilesRoundTrip(t *testing.T) {
+	input := `[{"name": "proj", "type": "directory", "contents": [
+		{"name": "Makefile", "type": "file"},
+		{"name": "LICENSE", "type": "file"},
+		{"name": "bin", "type": "directory"}
+	]}]`
+	root, err := NewJSONParser().Parse(context.Background(), input)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, NewTreeWriter().Write(&out, root))
+	assert.Equal(t, "proj\n├── [-]  Makefile\n├── [-]  LICENSE\n└── bin\n", out.String())
+
+	parsed, err := NewTreeParser().Parse(context.Background(), out.String())
+	require.NoError(t, err)
+	assert.True(t, root.Equal(parsed), "no mode is added on the way")
+}
+
+func TestTreeHashInName(t *testing.T) {
+	root, err := NewTreeParser().Parse(context.Background(), "notes\n└── my #1.txt\n")
+	require.NoError(t, err)
+	assert.Equal(t, "my #1.txt", root.Children[0].Name)
+	assert.Empty(t, root.Children[0].Comment)
+}
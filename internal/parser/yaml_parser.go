@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type yamlParser struct{}
+
+func NewYAMLParser() Parser {
+	return &yamlParser{}
+}
+
+// Parse reads a single root node in the same shape as the JSON format. Unlike
+// JSON the type may be left out, since YAML seeds are usually written by hand.
+func (p *yamlParser) Parse(ctx context.Context, yamlStr string) (*tree.Node, error) {
+	if strings.TrimSpace(yamlStr) == "" {
+		return nil, fmt.Errorf("no tree provided")
+	}
+
+	var root FileNode
+	decoder := yaml.NewDecoder(strings.NewReader(yamlStr))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlStr), &doc); err == nil && len(doc.Content) > 0 {
+		yamlLines(doc.Content[0], &root)
+	}
+
+	if err := p.validateNode(&root); err != nil {
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
+	}
+
+	treeNode, err := fileNodeToTreeNode(ctx, &root, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
+	}
+	return treeNode, nil
+}
+
+// validateNode checks the required fields and fills in any missing types
+func (p *yamlParser) validateNode(node *FileNode) error {
+	if node.Name == "" {
+		return fmt.Errorf("missing name field")
+	}
+
+	switch node.Type {
+	case "file", "directory":
+	case "":
+		node.Type = "directory"
+		if len(node.Contents) == 0 && looksLikeFile(node.Name) {
+			node.Type = "file"
+		}
+	default:
+		return fmt.Errorf("invalid type %q for %s", node.Type, node.Name)
+	}
+
+	for i := range node.Contents {
+		if err := p.validateNode(&node.Contents[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// yamlLines records where each node starts, walking the document alongside
+// the decoded nodes
+func yamlLines(n *yaml.Node, node *FileNode) {
+	node.Line = n.Line
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != "contents" {
+			continue
+		}
+		items := n.Content[i+1].Content
+		for j := range node.Contents {
+			if j < len(items) {
+				yamlLines(items[j], &node.Contents[j])
+			}
+		}
+	}
+}
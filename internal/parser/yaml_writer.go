@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type yamlWriter struct {
+	indent int
+}
+
+func NewYAMLWriter() Writer {
+	return &yamlWriter{indent: defaultIndent}
+}
+
+// NewIndentedYAMLWriter indents nested nodes by width spaces
+func NewIndentedYAMLWriter(width int) Writer {
+	return &yamlWriter{indent: width}
+}
+
+func (w *yamlWriter) Write(out io.Writer, root *tree.Node) error {
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(w.indent)
+	if err := encoder.Encode(treeNodeToFileNode(root)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,406 @@
+package planter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type Option func(*config)
+
+type config struct {
+	jobs     int
+	entries  func(Entry)
+	backup   func(path string) error
+	conflict Conflict
+	// dest is where planting happens, nothing is written outside of it
+	dest string
+}
+
+// Conflict decides what happens to a file that already exists. Existing
+// directories are always planted into.
+type Conflict string
+
+const (
+	ConflictOverwrite Conflict = "overwrite"
+	ConflictSkip      Conflict = "skip"
+	ConflictError     Conflict = "error"
+)
+
+// Entry describes what planting did to a single path.
+type Entry struct {
+	Path string
+	// Action is one of the logger Event constants
+	Action string
+	IsFile bool
+	// Mode is the permission bits the path ends up with
+	Mode os.FileMode
+	// Bytes is the size of the content written, zero for directories and skipped paths
+	Bytes int64
+}
+
+// created reports whether the path did not exist before planting
+func (e *Entry) created() bool {
+	return e != nil && e.Action == logger.EventPlanted
+}
+
+// WithJobs plants sibling subtrees on up to n workers. A directory is always
+// created before anything inside it. Values below 1 mean 1.
+func WithJobs(n int) Option {
+	return func(c *config) {
+		c.jobs = n
+	}
+}
+
+// WithEntries passes an Entry for every planted path to fn, in the order a
+// single job would have planted them, once planting has succeeded.
+func WithEntries(fn func(Entry)) Option {
+	return func(c *config) {
+		c.entries = fn
+	}
+}
+
+// WithBackup calls fn before an existing file is overwritten, so it can be
+// copied away. fn may be called from several workers at once. Planting the
+// file fails when fn does.
+func WithBackup(fn func(path string) error) Option {
+	return func(c *config) {
+		c.backup = fn
+	}
+}
+
+// WithConflict sets what happens to existing files, they are overwritten by default.
+func WithConflict(policy Conflict) Option {
+	return func(c *config) {
+		c.conflict = policy
+	}
+}
+
+// describe fills in the mode of e, which is only known once the path exists
+// unless the node asks for one
+func (c *config) describe(e *Entry, node *tree.Node) {
+	if c.entries == nil || e == nil {
+		return
+	}
+	// a skipped file keeps the mode it had
+	e.Mode = node.Mode
+	if e.Mode != 0 && !(e.IsFile && e.Action == logger.EventSkipped) {
+		return
+	}
+	if info, err := os.Lstat(e.Path); err == nil {
+		e.Mode = info.Mode().Perm()
+	}
+}
+
+// Plant creates the tree below dest, logging every planted path. Cancelling
+// ctx stops planting before the next node, so no file is left half written.
+// The paths that did not exist before are returned in creation order, also
+// when planting fails, so they can be handed to Rollback.
+//
+// With several jobs the error returned is the one planting with a single job
+// would have hit first, so failures are reported the same way on every run.
+func Plant(ctx context.Context, root *tree.Node, dest string, logger logger.Logger, opts ...Option) ([]string, error) {
+	cfg := newConfig(opts)
+	cfg.dest = dest
+	if root == nil {
+		return nil, nil
+	}
+
+	p := newPlanter(ctx, root, logger, cfg)
+	p.push(task{node: root, path: dest, index: 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work()
+		}()
+	}
+	wg.Wait()
+
+	return p.finish()
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{jobs: 1, conflict: ConflictOverwrite}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.jobs < 1 {
+		cfg.jobs = 1
+	}
+	return cfg
+}
+
+// Within reports whether path, once cleaned, is dir or somewhere below it.
+func Within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Rollback removes created paths in reverse order. Directories are only removed
+// when empty, so anything added to them in the meantime is kept.
+func Rollback(created []string) error {
+	// a read-only directory has to be writable again before it can be emptied
+	for _, path := range created {
+		if info, err := os.Lstat(path); err == nil && info.IsDir() {
+			os.Chmod(path, 0755)
+		}
+	}
+
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := os.Remove(created[i]); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// task is a node waiting to be planted at path. index is its position in a
+// depth-first walk, which is the order a single job plants in.
+type task struct {
+	node  *tree.Node
+	path  string
+	index int
+	entry *Entry
+}
+
+type planter struct {
+	ctx    context.Context
+	logger logger.Logger
+	cfg    *config
+	// preorder holds the depth-first index of every node
+	preorder map[*tree.Node]int
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// stack is LIFO so a single worker plants depth-first, like the recursive planter did
+	stack  []task
+	active int
+
+	created  []task
+	planted  []task
+	modes    []task
+	err      error
+	errIndex int
+}
+
+func newPlanter(ctx context.Context, root *tree.Node, logger logger.Logger, cfg *config) *planter {
+	p := &planter{
+		ctx:      ctx,
+		logger:   logger,
+		cfg:      cfg,
+		preorder: make(map[*tree.Node]int),
+		errIndex: math.MaxInt,
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	index := 0
+	root.Visit(func(_ string, node *tree.Node) bool {
+		p.preorder[node] = index
+		index++
+		return true
+	})
+	return p
+}
+
+// push queues a task. The caller must hold mu unless no worker has started.
+func (p *planter) push(t task) {
+	p.stack = append(p.stack, t)
+}
+
+// work plants tasks until the stack is empty and no other worker can add to it
+func (p *planter) work() {
+	for {
+		p.mu.Lock()
+		for len(p.stack) == 0 && p.active > 0 {
+			p.cond.Wait()
+		}
+		if len(p.stack) == 0 {
+			p.cond.Broadcast()
+			p.mu.Unlock()
+			return
+		}
+
+		t := p.stack[len(p.stack)-1]
+		p.stack = p.stack[:len(p.stack)-1]
+		// anything after the first failure is skipped, as it would be with one job
+		if t.index > p.errIndex {
+			p.mu.Unlock()
+			continue
+		}
+		p.active++
+		p.mu.Unlock()
+
+		entry, err := plantNode(p.ctx, p.logger, p.cfg, t.path, t.node)
+		p.cfg.describe(entry, t.node)
+
+		p.mu.Lock()
+		p.active--
+		if entry.created() {
+			p.created = append(p.created, t)
+		}
+		if entry != nil && p.cfg.entries != nil {
+			t.entry = entry
+			p.planted = append(p.planted, t)
+		}
+		if err != nil {
+			if t.index < p.errIndex {
+				p.err, p.errIndex = err, t.index
+			}
+		} else {
+			if !t.node.IsFile && t.node.Mode != 0 && t.node.Name != "." {
+				p.modes = append(p.modes, t)
+			}
+			for i := len(t.node.Children) - 1; i >= 0; i-- {
+				child := t.node.Children[i]
+				p.push(task{node: child, path: p.childPath(t), index: p.preorder[child]})
+			}
+		}
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// childPath is where the children of t are planted
+func (p *planter) childPath(t task) string {
+	if t.node.Name == "." {
+		return t.path
+	}
+	return filepath.Join(t.path, t.node.Name)
+}
+
+// plantNode creates a single node below parentPath and describes what it did.
+// The entry is nil when nothing was touched. Directory modes are left to the
+// caller, since they can only be applied once the directory has been filled.
+func plantNode(ctx context.Context, log logger.Logger, cfg *config, parentPath string, node *tree.Node) (*Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// the "." root is dest itself
+	if node.Name == "." {
+		return nil, nil
+	}
+
+	permissions := os.FileMode(0755)
+	currentPath := filepath.Join(parentPath, node.Name)
+	// the parsers reject such names, but trees can also be built by hand
+	if !Within(cfg.dest, currentPath) {
+		return nil, fmt.Errorf("refusing to plant %q outside of %s", node.Name, cfg.dest)
+	}
+	info, statErr := os.Lstat(currentPath)
+	existed := statErr == nil
+
+	if !node.IsFile {
+		if err := os.MkdirAll(currentPath, permissions); err != nil {
+			return nil, fmt.Errorf("failed to create directory %s: %w", currentPath, err)
+		}
+		entry := &Entry{Path: currentPath, Action: nodeEvent(existed, logger.EventSkipped)}
+		log.Node(entry.Action, currentPath, false)
+		return entry, nil
+	}
+
+	// ensure parent directory exists
+	parentDir := filepath.Dir(currentPath)
+	if err := os.MkdirAll(parentDir, permissions); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", parentDir, err)
+	}
+
+	if existed {
+		switch cfg.conflict {
+		case ConflictSkip:
+			entry := &Entry{Path: currentPath, Action: logger.EventSkipped, IsFile: true}
+			log.Node(entry.Action, currentPath, true)
+			return entry, nil
+		case ConflictError:
+			return nil, fmt.Errorf("failed to create file %s: it already exists", currentPath)
+		}
+	}
+	if existed && cfg.backup != nil && info.Mode().IsRegular() {
+		if err := cfg.backup(currentPath); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", currentPath, err)
+		}
+	}
+
+	f, err := os.Create(currentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file %s: %w", currentPath, err)
+	}
+
+	entry := &Entry{
+		Path:   currentPath,
+		Action: nodeEvent(existed, logger.EventOverwritten),
+		IsFile: true,
+		Bytes:  int64(len(node.Content)),
+	}
+	_, err = f.WriteString(node.Content)
+	f.Close()
+	if err != nil {
+		return entry, fmt.Errorf("failed to write file %s: %w", currentPath, err)
+	}
+
+	if node.Mode != 0 {
+		if err := os.Chmod(currentPath, node.Mode); err != nil {
+			return entry, fmt.Errorf("failed to set permissions on %s: %w", currentPath, err)
+		}
+	}
+
+	log.Node(entry.Action, currentPath, true)
+	return entry, nil
+}
+
+// nodeEvent is EventPlanted for new paths and ifExisted otherwise
+func nodeEvent(existed bool, ifExisted string) string {
+	if existed {
+		return ifExisted
+	}
+	return logger.EventPlanted
+}
+
+// finish applies directory permissions and lists the created paths in creation order
+func (p *planter) finish() ([]string, error) {
+	sort.Slice(p.created, func(i, j int) bool {
+		return p.created[i].index < p.created[j].index
+	})
+	created := make([]string, 0, len(p.created))
+	for _, t := range p.created {
+		created = append(created, filepath.Join(t.path, t.node.Name))
+	}
+
+	if p.err != nil {
+		return created, p.err
+	}
+
+	// permissions go on last, deepest first, so a read-only directory can still be filled
+	sort.Slice(p.modes, func(i, j int) bool {
+		return p.modes[i].index > p.modes[j].index
+	})
+	for _, t := range p.modes {
+		path := filepath.Join(t.path, t.node.Name)
+		if err := os.Chmod(path, t.node.Mode); err != nil {
+			return created, fmt.Errorf("failed to set permissions on %s: %w", path, err)
+		}
+	}
+
+	if p.cfg.entries != nil {
+		sort.Slice(p.planted, func(i, j int) bool {
+			return p.planted[i].index < p.planted[j].index
+		})
+		for _, t := range p.planted {
+			p.cfg.entries(*t.entry)
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,243 @@
+package planter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder keeps the planted paths in the order they were logged
+type recorder struct {
+	logger.Logger
+	mu    sync.Mutex
+	paths []string
+}
+
+func newRecorder() *recorder {
+	return &recorder{Logger: logger.Discard()}
+}
+
+func (r *recorder) Node(event, path string, isFile bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, path)
+}
+
+// wide builds a tree of width directories each holding width files
+func wide(width int) *tree.Node {
+	root := tree.NewDir("root")
+	for i := 0; i < width; i++ {
+		dir := tree.NewDir(fmt.Sprintf("dir%d", i))
+		for j := 0; j < width; j++ {
+			dir.Children = append(dir.Children, tree.NewFile(fmt.Sprintf("file%d.txt", j)))
+		}
+		root.Children = append(root.Children, dir)
+	}
+	return root
+}
+
+func TestPlantJobs(t *testing.T) {
+	for _, jobs := range []int{1, 4, 16} {
+		t.Run(fmt.Sprintf("%d jobs", jobs), func(t *testing.T) {
+			dest := t.TempDir()
+			rec := newRecorder()
+
+			created, err := Plant(context.Background(), wide(10), dest, rec, WithJobs(jobs))
+			require.NoError(t, err)
+			assert.Len(t, created, 111)
+
+			// every path is logged after its parent
+			seen := map[string]bool{dest: true}
+			for _, path := range rec.paths {
+				assert.True(t, seen[filepath.Dir(path)], "%s planted before its parent", path)
+				seen[path] = true
+			}
+
+			// created paths are in depth-first order whatever the job count
+			assert.Equal(t, filepath.Join(dest, "root"), created[0])
+			assert.Equal(t, filepath.Join(dest, "root", "dir0"), created[1])
+			assert.Equal(t, filepath.Join(dest, "root", "dir0", "file0.txt"), created[2])
+		})
+	}
+}
+
+func TestPlantSingleJobIsDepthFirst(t *testing.T) {
+	rec := newRecorder()
+	dest := t.TempDir()
+
+	_, err := Plant(context.Background(), wide(2), dest, rec)
+	require.NoError(t, err)
+
+	var rel []string
+	for _, path := range rec.paths {
+		r, _ := filepath.Rel(dest, path)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	assert.Equal(t, []string{
+		"root",
+		"root/dir0", "root/dir0/file0.txt", "root/dir0/file1.txt",
+		"root/dir1", "root/dir1/file0.txt", "root/dir1/file1.txt",
+	}, rel)
+}
+
+func TestPlantDeterministicError(t *testing.T) {
+	root := wide(8)
+	// files named like existing directories fail, the first in depth-first order is dir2
+	for _, i := range []int{6, 2, 5} {
+		root.Children[i].Children[3] = tree.NewFile("blocked")
+	}
+
+	for run := 0; run < 20; run++ {
+		dest := t.TempDir()
+		for _, i := range []int{2, 5, 6} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dest, "root", fmt.Sprintf("dir%d", i), "blocked"), 0755))
+		}
+
+		_, err := Plant(context.Background(), root, dest, newRecorder(), WithJobs(8))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), filepath.Join("dir2", "blocked"))
+	}
+}
+
+func TestPlantModes(t *testing.T) {
+	dest := t.TempDir()
+	locked := tree.NewDir("locked", tree.NewDir("inner", tree.NewFile("a.txt")))
+	locked.Mode = 0555
+	script := tree.NewFile("run.sh")
+	script.Mode = 0700
+	root := tree.NewDir("root", locked, script)
+
+	_, err := Plant(context.Background(), root, dest, newRecorder(), WithJobs(4))
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Chmod(filepath.Join(dest, "root", "locked"), 0755) })
+
+	info, err := os.Stat(filepath.Join(dest, "root", "locked"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0555), info.Mode().Perm())
+	assert.FileExists(t, filepath.Join(dest, "root", "locked", "inner", "a.txt"))
+
+	info, err = os.Stat(filepath.Join(dest, "root", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestPlantEntries(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "root"), 0755))
+	require.NoError(t, os.Chmod(filepath.Join(dest, "root"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "root", "old.txt"), []byte("old"), 0644))
+
+	script := tree.NewFile("run.sh")
+	script.Mode = 0700
+	script.Content = "#!/bin/sh\n"
+	old := tree.NewFile("old.txt")
+	old.Content = "new"
+	root := tree.NewDir("root", tree.NewDir("empty"), script, old)
+
+	var entries []Entry
+	_, err := Plant(context.Background(), root, dest, newRecorder(), WithJobs(4), WithEntries(func(e Entry) {
+		entries = append(entries, e)
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, entries, 4)
+	assert.Equal(t, Entry{Path: filepath.Join(dest, "root"), Action: logger.EventSkipped, Mode: 0755}, entries[0])
+	assert.Equal(t, filepath.Join(dest, "root", "empty"), entries[1].Path)
+	assert.Equal(t, logger.EventPlanted, entries[1].Action)
+	assert.Equal(t, Entry{Path: filepath.Join(dest, "root", "run.sh"), Action: logger.EventPlanted, IsFile: true, Mode: 0700, Bytes: 10}, entries[2])
+	assert.Equal(t, logger.EventOverwritten, entries[3].Action)
+	assert.Equal(t, int64(3), entries[3].Bytes)
+}
+
+func TestRollback(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "root", "dir1"), 0755))
+
+	created, err := Plant(context.Background(), wide(3), dest, newRecorder(), WithJobs(4))
+	require.NoError(t, err)
+	assert.NotContains(t, created, filepath.Join(dest, "root"))
+	assert.NotContains(t, created, filepath.Join(dest, "root", "dir1"))
+
+	require.NoError(t, Rollback(created))
+	entries, err := os.ReadDir(filepath.Join(dest, "root"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "dir1", entries[0].Name())
+}
+
+func TestPlantCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	created, err := Plant(ctx, wide(4), t.TempDir(), newRecorder(), WithJobs(4))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, created)
+}
+
+func TestPlantConflict(t *testing.T) {
+	root := func() *tree.Node {
+		readme := tree.NewFile("README.md")
+		readme.Content = "planted"
+		return tree.NewDir("root", readme, tree.NewFile("new.txt"))
+	}
+	existing := func(t *testing.T) string {
+		dest := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dest, "root"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dest, "root", "README.md"), []byte("mine"), 0644))
+		return dest
+	}
+
+	t.Run("overwrite", func(t *testing.T) {
+		dest := existing(t)
+		_, err := Plant(context.Background(), root(), dest, newRecorder())
+		require.NoError(t, err)
+		data, _ := os.ReadFile(filepath.Join(dest, "root", "README.md"))
+		assert.Equal(t, "planted", string(data))
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		dest := existing(t)
+		var actions []string
+		created, err := Plant(context.Background(), root(), dest, newRecorder(), WithConflict(ConflictSkip), WithEntries(func(e Entry) {
+			actions = append(actions, e.Action)
+		}))
+		require.NoError(t, err)
+		data, _ := os.ReadFile(filepath.Join(dest, "root", "README.md"))
+		assert.Equal(t, "mine", string(data))
+		assert.Equal(t, []string{filepath.Join(dest, "root", "new.txt")}, created)
+		assert.Equal(t, []string{logger.EventSkipped, logger.EventSkipped, logger.EventPlanted}, actions)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		dest := existing(t)
+		_, err := Plant(context.Background(), root(), dest, newRecorder(), WithConflict(ConflictError))
+		assert.ErrorContains(t, err, "README.md: it already exists")
+		data, _ := os.ReadFile(filepath.Join(dest, "root", "README.md"))
+		assert.Equal(t, "mine", string(data))
+	})
+}
+
+func TestPlantOutsideDest(t *testing.T) {
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "dest")
+	require.NoError(t, os.Mkdir(dest, 0755))
+	root := tree.NewDir("root", tree.NewFile(filepath.Join("..", "..", "escaped.txt")))
+
+	_, err := Plant(context.Background(), root, dest, newRecorder())
+	assert.ErrorContains(t, err, "outside of "+dest)
+	assert.NoFileExists(t, filepath.Join(parent, "escaped.txt"))
+
+	s := NewStream(context.Background(), dest, newRecorder(), true)
+	err = s.Emit("", tree.NewFile(filepath.Join("..", "escaped.txt")))
+	assert.ErrorContains(t, err, "outside of "+dest)
+	assert.ErrorContains(t, s.Emit("..", tree.NewFile("escaped.txt")), "outside of "+dest)
+	assert.NoFileExists(t, filepath.Join(parent, "escaped.txt"))
+}
@@ -0,0 +1,92 @@
+package planter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Stream plants nodes one at a time as a streaming parser emits them, so the
+// tree never has to be held in memory. Its Emit method is a formats.EmitFunc.
+type Stream struct {
+	ctx    context.Context
+	dest   string
+	logger logger.Logger
+	record bool
+	cfg    *config
+
+	created     []string
+	modes       []pendingMode
+	Directories int
+	Files       int
+}
+
+type pendingMode struct {
+	path string
+	mode os.FileMode
+}
+
+// NewStream plants below dest. Created paths are only kept when record is
+// set, as they are the one thing that grows with the size of the tree.
+// WithJobs is ignored, WithEntries gets every entry as soon as it is planted.
+func NewStream(ctx context.Context, dest string, logger logger.Logger, record bool, opts ...Option) *Stream {
+	cfg := newConfig(opts)
+	cfg.dest = dest
+	return &Stream{
+		ctx:    ctx,
+		dest:   dest,
+		logger: logger,
+		record: record,
+		cfg:    cfg,
+	}
+}
+
+// Emit plants node inside dir, which has been planted by an earlier call.
+func (s *Stream) Emit(dir string, node *tree.Node) error {
+	parentPath := filepath.Join(s.dest, filepath.FromSlash(dir))
+	if !Within(s.dest, parentPath) {
+		return fmt.Errorf("refusing to plant %q outside of %s", dir, s.dest)
+	}
+
+	entry, err := plantNode(s.ctx, s.logger, s.cfg, parentPath, node)
+	if entry.created() && s.record {
+		s.created = append(s.created, entry.Path)
+	}
+	if err != nil {
+		return err
+	}
+	if entry != nil && s.cfg.entries != nil {
+		s.cfg.describe(entry, node)
+		s.cfg.entries(*entry)
+	}
+
+	if node.IsFile {
+		s.Files++
+		return nil
+	}
+	s.Directories++
+	if node.Mode != 0 && node.Name != "." {
+		s.modes = append(s.modes, pendingMode{path: filepath.Join(parentPath, node.Name), mode: node.Mode})
+	}
+	return nil
+}
+
+// Finish applies directory modes once everything has been planted. It returns
+// the created paths in creation order when recording, also after a failure.
+func (s *Stream) Finish(err error) ([]string, error) {
+	if err != nil {
+		return s.created, err
+	}
+
+	// nodes arrive depth-first, so going backwards sets the deepest directories first
+	for i := len(s.modes) - 1; i >= 0; i-- {
+		if err := os.Chmod(s.modes[i].path, s.modes[i].mode); err != nil {
+			return s.created, fmt.Errorf("failed to set permissions on %s: %w", s.modes[i].path, err)
+		}
+	}
+	return s.created, nil
+}
@@ -0,0 +1,37 @@
+package runner
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/jpwallace22/seed/internal/config"
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/spf13/cobra"
+)
+
+type ConfigRunner struct {
+	ctx    *ctx.SeedContext
+	config *config.Config
+}
+
+func NewConfigRunner(cobra *cobra.Command, ctx *ctx.SeedContext, config *config.Config) Runner {
+	return &ConfigRunner{ctx: ctx, config: config}
+}
+
+func (r *ConfigRunner) Run(args []string) error {
+	w := tabwriter.NewWriter(r.ctx.Out, 0, 4, 2, ' ', 0)
+	for _, entry := range r.config.Entries() {
+		fmt.Fprintf(w, "%s\t%s\t(%s)\n", entry.Key, entry.Value, entry.Source)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.config.Files) == 0 {
+		r.ctx.Logger.Info("No config file found")
+	}
+	for _, file := range r.config.Files {
+		r.ctx.Logger.Info("Loaded %s", file)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type ConvertRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+func NewConvertRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &ConvertRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *ConvertRunner) Run(args []string) error {
+	flags := r.ctx.Flags.Convert
+
+	text, err := r.input.read(args)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	tree, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+
+	opts := []seed.WriteOption{seed.WithIndent(r.ctx.Flags.Output.Indent)}
+	if flags.ASCII {
+		opts = append(opts, seed.WithASCII())
+	}
+	return tree.Write(r.ctx.Out, seed.Format(flags.To), opts...)
+}
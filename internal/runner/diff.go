@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/spf13/cobra"
+)
+
+type DiffRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+type diffResult struct {
+	Dir        string        `json:"dir"`
+	Changes    []tree.Change `json:"changes"`
+	Missing    int           `json:"missing"`
+	Extra      int           `json:"extra"`
+	Mismatched int           `json:"mismatched"`
+}
+
+func NewDiffRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &DiffRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *DiffRunner) Run(args []string) error {
+	flags := r.ctx.Flags.Diff
+	if flags.Output != "text" && flags.Output != "json" {
+		return fmt.Errorf("invalid output %q, must be one of: text, json", flags.Output)
+	}
+
+	text, dir, err := r.input.readWithDir(args)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	expected, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+
+	opts := ignoreOptions(r.ctx.Flags.Ignore)
+	harvested, err := seed.Harvest(dir, append(opts, seed.WithHidden())...)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+	// what the harvest leaves out is not compared
+	if err := expected.Filter(dir, opts...); err != nil {
+		return fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+
+	result := diffResult{Dir: dir}
+	for _, change := range tree.Diff(expected.Node, harvested.Node) {
+		switch change.Kind {
+		case tree.Missing:
+			result.Missing++
+		case tree.Extra:
+			if flags.IgnoreExtra {
+				continue
+			}
+			result.Extra++
+		case tree.Mismatched:
+			result.Mismatched++
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if flags.Output == "json" {
+		if err := r.writeJSON(result); err != nil {
+			return err
+		}
+	} else {
+		r.writeText(result)
+	}
+
+	if len(result.Changes) > 0 {
+		return fmt.Errorf("%s does not match the seed: %d missing, %d extra, %d mismatched",
+			dir, result.Missing, result.Extra, result.Mismatched)
+	}
+	r.ctx.Logger.Success("%s matches the seed", dir)
+	return nil
+}
+
+func (r *DiffRunner) writeJSON(result diffResult) error {
+	if result.Changes == nil {
+		result.Changes = []tree.Change{}
+	}
+
+	encoder := json.NewEncoder(r.ctx.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// writeText prints the changes like a unified diff, from the seed to the directory
+func (r *DiffRunner) writeText(result diffResult) {
+	if len(result.Changes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(r.ctx.Out, "--- seed\n+++ %s\n", result.Dir)
+	for _, change := range result.Changes {
+		switch change.Kind {
+		case tree.Missing:
+			fmt.Fprintf(r.ctx.Out, "-%s\n", displayPath(change.Path, change.Expected))
+		case tree.Extra:
+			fmt.Fprintf(r.ctx.Out, "+%s\n", displayPath(change.Path, change.Actual))
+		case tree.Mismatched:
+			fmt.Fprintf(r.ctx.Out, "!%s (expected %s, found %s)\n", change.Path, change.Expected, change.Actual)
+		}
+	}
+}
+
+func displayPath(path, kind string) string {
+	if kind == "directory" {
+		return path + "/"
+	}
+	return path
+}
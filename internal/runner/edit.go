@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/editor"
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/spf13/cobra"
+)
+
+type EditRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+func NewEditRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &EditRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *EditRunner) Run(args []string) error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return errors.New("seed edit needs a terminal")
+	}
+
+	root, path, format, err := r.load(args)
+	if err != nil {
+		return err
+	}
+
+	e := editor.New(root, path,
+		editor.WithFormat(format),
+		editor.WithIndent(r.ctx.Flags.Output.Indent),
+		editor.WithDest(r.ctx.Flags.Edit.Target),
+		editor.WithColor(logger.ColorEnabled(os.Stdout)),
+	)
+	return editor.Run(e)
+}
+
+// load reads the seed to edit along with the path and format it is saved as.
+// A file that does not exist yet, or no input at all, is an empty seed.
+func (r *EditRunner) load(args []string) (*tree.Node, string, seed.Format, error) {
+	var path, text string
+	if len(args) > 0 {
+		path = args[0]
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return tree.NewDir("."), path, seed.DetectFormat(path, seed.FormatTree), nil
+		}
+		if err != nil {
+			return nil, "", "", fmt.Errorf("file read error: %w", err)
+		}
+		text = string(data)
+	} else {
+		var err error
+		text, err = r.input.read(nil)
+		if errors.Is(err, errNoInput) {
+			return tree.NewDir("."), "", seed.FormatTree, nil
+		}
+		if err != nil {
+			return nil, "", "", err
+		}
+		path = r.ctx.Flags.Root.FilePath
+	}
+
+	// the seed is saved in the format it was read in, unless its extension says otherwise
+	format := seed.Format(r.ctx.Flags.Root.Format)
+	if format == seed.FormatAuto {
+		format = seed.SniffFormat([]byte(text), seed.FormatTree)
+	}
+	parsed, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), format)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+	if path != "" {
+		format = seed.DetectFormat(path, format)
+	}
+	return parsed.Node, path, format, nil
+}
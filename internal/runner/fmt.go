@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type FmtRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+func NewFmtRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &FmtRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *FmtRunner) Run(args []string) error {
+	if len(args) == 0 {
+		return r.formatInput()
+	}
+
+	unformatted := 0
+	for _, path := range args {
+		changed, err := r.formatFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to format %s: %w", path, err)
+		}
+		if changed {
+			unformatted++
+		}
+	}
+
+	if r.ctx.Flags.Fmt.Check && unformatted > 0 {
+		return fmt.Errorf("%d of %d files are not formatted", unformatted, len(args))
+	}
+	return nil
+}
+
+// formatInput formats a seed from the clipboard or --file and prints it. With
+// --check nothing is printed, it only fails when the seed is not formatted.
+func (r *FmtRunner) formatInput() error {
+	text, err := r.input.read(nil)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	formatted, err := r.format(text, seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return err
+	}
+
+	if r.ctx.Flags.Fmt.Check {
+		if formatted != text {
+			return fmt.Errorf("the seed is not formatted")
+		}
+		return nil
+	}
+	_, err = fmt.Fprint(r.ctx.Out, formatted)
+	return err
+}
+
+// formatFile reports whether the file differs from its canonical form
+func (r *FmtRunner) formatFile(path string) (bool, error) {
+	fmtFlags := r.ctx.Flags.Fmt
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("file read error: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("file read error: %w", err)
+	}
+
+	format := seed.DetectFormat(path, seed.Format(r.ctx.Flags.Root.Format))
+	formatted, err := r.format(string(data), format)
+	if err != nil {
+		return false, err
+	}
+	changed := formatted != string(data)
+
+	if !fmtFlags.Check && !fmtFlags.Write {
+		_, err = fmt.Fprint(r.ctx.Out, formatted)
+		return changed, err
+	}
+
+	if changed && fmtFlags.Check {
+		fmt.Fprintln(r.ctx.Out, path)
+	}
+	if changed && fmtFlags.Write {
+		if err := os.WriteFile(path, []byte(formatted), info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("file write error: %w", err)
+		}
+		r.ctx.Logger.Info("Formatted %s", path)
+	}
+	return changed, nil
+}
+
+// format writes the seed back in the format it was read in
+func (r *FmtRunner) format(text string, format seed.Format) (string, error) {
+	if format == seed.FormatAuto {
+		format = seed.SniffFormat([]byte(text), seed.FormatTree)
+	}
+
+	parsed, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), format)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+	if r.ctx.Flags.Fmt.Sort {
+		parsed.Sort()
+	}
+
+	var out strings.Builder
+	if err := parsed.Write(&out, format, seed.WithIndent(r.ctx.Flags.Output.Indent)); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/ctx"
+	mocklogger "github.com/jpwallace22/seed/pkg/logger/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	unformattedSeed = `project
+|-- src
+|   ` + "`" + `-- main.go
+├── README.md
+└── cmd/`
+
+	canonicalSeed = `project
+├── src
+│   └── main.go
+├── README.md
+└── cmd
+`
+
+	sortedSeed = `project
+├── cmd
+├── src
+│   └── main.go
+└── README.md
+`
+)
+
+func buildFmtRunner(fmtFlags flags.FmtFlags) (*FmtRunner, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	testCtx := &ctx.SeedContext{
+		Logger: mocklogger.New(),
+		Cobra:  &cobra.Command{Use: "test"},
+		Flags: flags.Flags{
+			Root: flags.RootFlags{Format: flags.Formats.Tree},
+			Fmt:  fmtFlags,
+		},
+		Out: out,
+	}
+	return &FmtRunner{ctx: testCtx}, out
+}
+
+func writeSeed(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "layout.seed")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestFmtPrintsCanonicalForm(t *testing.T) {
+	runner, out := buildFmtRunner(flags.FmtFlags{})
+	path := writeSeed(t, unformattedSeed)
+
+	require.NoError(t, runner.Run([]string{path}))
+	assert.Equal(t, canonicalSeed, out.String())
+}
+
+func TestFmtSort(t *testing.T) {
+	runner, out := buildFmtRunner(flags.FmtFlags{Sort: true})
+	path := writeSeed(t, unformattedSeed)
+
+	require.NoError(t, runner.Run([]string{path}))
+	assert.Equal(t, sortedSeed, out.String())
+}
+
+func TestFmtCheck(t *testing.T) {
+	t.Run("unformatted file fails", func(t *testing.T) {
+		runner, out := buildFmtRunner(flags.FmtFlags{Check: true})
+		path := writeSeed(t, unformattedSeed)
+
+		err := runner.Run([]string{path})
+		assert.Error(t, err)
+		assert.Equal(t, path+"\n", out.String())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, unformattedSeed, string(data), "check must not modify the file")
+	})
+
+	t.Run("formatted file passes", func(t *testing.T) {
+		runner, out := buildFmtRunner(flags.FmtFlags{Check: true})
+		path := writeSeed(t, canonicalSeed)
+
+		assert.NoError(t, runner.Run([]string{path}))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("seed from --file", func(t *testing.T) {
+		runner, out := buildFmtRunner(flags.FmtFlags{Check: true})
+		runner.ctx.Flags.Root.FilePath = writeSeed(t, unformattedSeed)
+		runner.input = newSeedInput(runner.ctx)
+
+		assert.ErrorContains(t, runner.Run(nil), "not formatted")
+		assert.Empty(t, out.String())
+
+		runner.ctx.Flags.Root.FilePath = writeSeed(t, canonicalSeed)
+		runner.input = newSeedInput(runner.ctx)
+		assert.NoError(t, runner.Run(nil))
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestFmtWrite(t *testing.T) {
+	runner, out := buildFmtRunner(flags.FmtFlags{Write: true})
+	path := writeSeed(t, unformattedSeed)
+
+	require.NoError(t, runner.Run([]string{path}))
+	assert.Empty(t, out.String())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, canonicalSeed, string(data))
+}
+
+func TestFmtInvalidFile(t *testing.T) {
+	runner, _ := buildFmtRunner(flags.FmtFlags{Check: true})
+	err := runner.Run([]string{filepath.Join(t.TempDir(), "missing.seed")})
+	assert.ErrorContains(t, err, "file read error")
+}
@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type HarvestRunner struct {
+	ctx *ctx.SeedContext
+}
+
+func NewHarvestRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &HarvestRunner{ctx: ctx}
+}
+
+func (r *HarvestRunner) Run(args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	opts := ignoreOptions(r.ctx.Flags.Ignore)
+	if r.ctx.Flags.Harvest.All {
+		opts = append(opts, seed.WithHidden())
+	}
+	if ref := r.ctx.Flags.Harvest.GitRef; ref != "" {
+		opts = append(opts, seed.WithGitRef(ref))
+	}
+
+	tree, err := seed.Harvest(dir, opts...)
+	if err != nil {
+		return fmt.Errorf("unable to harvest %s: %w", dir, err)
+	}
+
+	return tree.Write(r.ctx.Out, seed.FormatTree)
+}
+
+// ignoreOptions turns the ignore flags into harvest options
+func ignoreOptions(f flags.IgnoreFlags) []seed.HarvestOption {
+	var opts []seed.HarvestOption
+	if !f.NoIgnore {
+		opts = append(opts, seed.WithIgnoreFiles())
+	}
+	if exclude := append(append([]string{}, f.Exclude...), f.Ignore...); len(exclude) > 0 {
+		opts = append(opts, seed.WithExclude(exclude...))
+	}
+	if len(f.Include) > 0 {
+		opts = append(opts, seed.WithInclude(f.Include...))
+	}
+	return opts
+}
@@ -0,0 +1,26 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreOptions(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{".git/objects", "build", "src"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "main.go"), nil, 0644))
+
+	// the patterns of the deprecated --ignore are excluded along with --exclude
+	opts := ignoreOptions(flags.IgnoreFlags{NoIgnore: true, Exclude: []string{".git"}, Ignore: []string{"build"}})
+	harvested, err := seed.Harvest(dir, append(opts, seed.WithHidden())...)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"src": false, "src/main.go": true}, harvested.Paths())
+}
@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+)
+
+// hookTrust decides whether the hooks a seed declares may run. Hooks are
+// arbitrary commands, so a seed has to be trusted with --trust-hooks, be
+// remembered as trusted, or be confirmed on the terminal.
+type hookTrust struct {
+	// disabled is set by --no-hooks
+	disabled bool
+	// trusted is set by --trust-hooks and for installed templates
+	trusted bool
+	// in reads the answer to the prompt, nil when not interactive
+	in  *bufio.Reader
+	out io.Writer
+	// store lists the digests of the seeds that are always trusted
+	store string
+}
+
+// allow reports whether the hooks of t may run, source being the seed it was
+// parsed from. It fails when t has hooks nobody can confirm.
+func (h *hookTrust) allow(hooks []seed.Hook, source string) (bool, error) {
+	if len(hooks) == 0 || h.disabled {
+		return false, nil
+	}
+
+	digest := sourceDigest(source)
+	if h.trusted || h.isStored(digest) {
+		return true, nil
+	}
+	if h.in == nil {
+		return false, fmt.Errorf("the seed declares %d hooks but is not trusted, use --trust-hooks to run them or --no-hooks to skip them", len(hooks))
+	}
+
+	fmt.Fprintf(h.out, "This seed runs %d commands:\n", len(hooks))
+	for _, hook := range hooks {
+		dir := hook.Dir
+		if dir == "" {
+			dir = "."
+		}
+		fmt.Fprintf(h.out, "  %-4s  %s  (in %s)\n", hook.Stage, hook.Command, dir)
+	}
+	fmt.Fprint(h.out, "Run them? [y]es, [N]o, [a]lways for this seed: ")
+
+	line, err := h.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(h.out)
+		return false, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	case "a", "always":
+		if err := h.remember(digest); err != nil {
+			return false, fmt.Errorf("unable to remember the seed as trusted: %w", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (h *hookTrust) isStored(digest string) bool {
+	if h.store == "" {
+		return false
+	}
+	data, err := os.ReadFile(h.store)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == digest {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *hookTrust) remember(digest string) error {
+	if h.store == "" {
+		return errors.New("no user config directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(h.store), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.store, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, digest); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func sourceDigest(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHooks = []seed.Hook{
+	{Stage: seed.HookPre, Command: "git init", Path: "app"},
+	{Stage: seed.HookPost, Command: "go mod tidy", Path: "app", Dir: "app"},
+}
+
+func newTestTrust(t *testing.T, input string) (*hookTrust, *bytes.Buffer) {
+	var out bytes.Buffer
+	h := &hookTrust{out: &out, store: filepath.Join(t.TempDir(), "seed", "trusted")}
+	if input != "" {
+		h.in = bufio.NewReader(strings.NewReader(input))
+	}
+	return h, &out
+}
+
+func TestHookTrust(t *testing.T) {
+	h, _ := newTestTrust(t, "")
+	_, err := h.allow(testHooks, "seed")
+	assert.ErrorContains(t, err, "the seed declares 2 hooks but is not trusted")
+
+	ok, err := h.allow(nil, "seed")
+	require.NoError(t, err)
+	assert.False(t, ok, "no hooks, nothing to run")
+
+	h.disabled = true
+	ok, err = h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	h.disabled, h.trusted = false, true
+	ok, err = h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestHookTrustPrompt(t *testing.T) {
+	h, out := newTestTrust(t, "n\n")
+	ok, err := h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, out.String(), "pre   git init  (in .)")
+	assert.Contains(t, out.String(), "post  go mod tidy  (in app)")
+
+	h, _ = newTestTrust(t, "y\n")
+	ok, err = h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, h.isStored(sourceDigest("seed")), "yes only trusts this once")
+
+	// always remembers the seed, and only that seed
+	h, _ = newTestTrust(t, "a\n")
+	ok, err = h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	h.in = nil
+	ok, err = h.allow(testHooks, "seed")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = h.allow(testHooks, "other seed")
+	assert.Error(t, err)
+}
+
+func TestTrustedTemplate(t *testing.T) {
+	user := t.TempDir()
+	assert.True(t, trustedTemplate(filepath.Join(user, "api.tree"), []string{user}))
+	assert.True(t, trustedTemplate(filepath.Join(user, "api.tree"), []string{user + "/"}))
+	assert.False(t, trustedTemplate(filepath.Join(t.TempDir(), "api.tree"), []string{user}))
+	assert.False(t, trustedTemplate(filepath.Join(user, "nested", "api.tree"), []string{user}))
+	assert.False(t, trustedTemplate(filepath.Join(user, "api.tree"), nil))
+}
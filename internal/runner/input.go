@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/clipboard"
+)
+
+var errNoInput = errors.New("no seed provided")
+
+// seedInput resolves the raw seed from whichever source the root flags select,
+// so every subcommand accepts the clipboard, a file or a string the same way.
+type seedInput struct {
+	ctx       *ctx.SeedContext
+	clipboard clipboard.Clipboard
+}
+
+func newSeedInput(ctx *ctx.SeedContext) seedInput {
+	return seedInput{
+		ctx:       ctx,
+		clipboard: clipboard.New(string(ctx.Flags.Root.ClipboardProvider)),
+	}
+}
+
+// read treats the first argument as the seed itself
+func (i seedInput) read(args []string) (string, error) {
+	flags := i.ctx.Flags.Root
+
+	switch {
+	case flags.FromClipboard:
+		return i.readClipboard()
+	case flags.FilePath != "":
+		return i.readFile(flags.FilePath)
+	case len(args) > 0:
+		return args[0], nil
+	}
+
+	return "", errNoInput
+}
+
+// readWithDir is used by commands shaped `<seed> [dir]`. The first argument is
+// a path to the seed unless the clipboard or --file already provide it.
+func (i seedInput) readWithDir(args []string) (seed string, dir string, err error) {
+	flags := i.ctx.Flags.Root
+	dir = "."
+
+	switch {
+	case flags.FromClipboard:
+		seed, err = i.readClipboard()
+	case flags.FilePath != "":
+		seed, err = i.readFile(flags.FilePath)
+	case len(args) > 0:
+		seed, err = i.readFile(args[0])
+		args = args[1:]
+	default:
+		return "", "", errNoInput
+	}
+
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	return seed, dir, err
+}
+
+func (i seedInput) readClipboard() (string, error) {
+	text, err := i.clipboard.PasteText()
+	if err != nil {
+		return "", fmt.Errorf("clipboard read error: %w", err)
+	}
+	return text, nil
+}
+
+func (i seedInput) readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("file read error: %w", err)
+	}
+	return string(data), nil
+}
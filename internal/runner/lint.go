@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/config"
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/lint"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type LintRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+type lintReport struct {
+	Violations []lint.Violation `json:"violations"`
+	Errors     int              `json:"errors"`
+	Warnings   int              `json:"warnings"`
+}
+
+func NewLintRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &LintRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *LintRunner) Run(args []string) error {
+	flags := r.ctx.Flags.Lint
+	if flags.Output != "text" && flags.Output != "json" {
+		return fmt.Errorf("invalid output %q, must be one of: text, json", flags.Output)
+	}
+
+	linter, err := lint.New(flags.Rules, flags.Options()...)
+	if err != nil {
+		return err
+	}
+	if linter.Empty() {
+		r.ctx.Logger.Warn("No lint rules are configured, add them to the lint section of %s or use --portable", config.ProjectFile)
+	}
+
+	text, err := r.input.read(args)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	tree, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+
+	report := lintReport{Violations: linter.Check(tree.Node)}
+	report.Errors, report.Warnings = lint.Count(report.Violations)
+
+	if flags.Output == "json" {
+		if report.Violations == nil {
+			report.Violations = []lint.Violation{}
+		}
+		encoder := json.NewEncoder(r.ctx.Out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		source := r.ctx.Flags.Root.FilePath
+		if source == "" {
+			source = "seed"
+		}
+		for _, v := range report.Violations {
+			writeViolation(r.ctx.Out, source, v)
+		}
+	}
+
+	if report.Errors > 0 {
+		return fmt.Errorf("the seed has %d lint errors and %d warnings", report.Errors, report.Warnings)
+	}
+	r.ctx.Logger.Success("Seed passes the lint rules with %d warnings", report.Warnings)
+	return nil
+}
+
+// writeViolation prints a violation the way compilers do, file and line first
+func writeViolation(w io.Writer, source string, v lint.Violation) {
+	location := source
+	if v.Line > 0 {
+		location = fmt.Sprintf("%s:%d", source, v.Line)
+	}
+	fmt.Fprintf(w, "%s: %s: %s\n", location, v.Severity, v)
+}
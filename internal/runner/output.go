@@ -0,0 +1,48 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/clipboard"
+)
+
+// clipboardOutput sends the output of another runner to the clipboard
+type clipboardOutput struct {
+	runner    Runner
+	ctx       *ctx.SeedContext
+	clipboard clipboard.Clipboard
+}
+
+// WithClipboardOutput copies whatever r prints to the clipboard instead, when
+// --to-clipboard is given. The output is copied even when r fails, as diff does
+// when it finds differences, as long as there is some.
+func WithClipboardOutput(ctx *ctx.SeedContext, r Runner) Runner {
+	if !ctx.Flags.Output.ToClipboard {
+		return r
+	}
+	return &clipboardOutput{
+		runner:    r,
+		ctx:       ctx,
+		clipboard: clipboard.New(string(ctx.Flags.Root.ClipboardProvider)),
+	}
+}
+
+func (c *clipboardOutput) Run(args []string) error {
+	var buf bytes.Buffer
+	out := c.ctx.Out
+	c.ctx.Out = &buf
+	err := c.runner.Run(args)
+	c.ctx.Out = out
+
+	if buf.Len() == 0 {
+		return err
+	}
+	if copyErr := c.clipboard.CopyText(buf.String()); copyErr != nil {
+		return errors.Join(err, fmt.Errorf("unable to copy to the clipboard: %w", copyErr))
+	}
+	c.ctx.Logger.Success("Copied %d lines to the clipboard", bytes.Count(buf.Bytes(), []byte("\n")))
+	return err
+}
@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/ctx"
+	mocklogger "github.com/jpwallace22/seed/pkg/logger/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// printer is a runner that prints its output and fails with err
+type printer struct {
+	ctx    *ctx.SeedContext
+	output string
+	err    error
+}
+
+func (p *printer) Run(args []string) error {
+	fmt.Fprint(p.ctx.Out, p.output)
+	return p.err
+}
+
+func buildOutputRunner(output string, err error) (*clipboardOutput, *MockClipboard, *bytes.Buffer) {
+	var out bytes.Buffer
+	testCtx := &ctx.SeedContext{
+		Logger: mocklogger.New(),
+		Flags:  flags.Flags{Output: flags.OutputFlags{ToClipboard: true}},
+		Out:    &out,
+	}
+	mockClipboard := new(MockClipboard)
+
+	runner := WithClipboardOutput(testCtx, &printer{ctx: testCtx, output: output, err: err}).(*clipboardOutput)
+	runner.clipboard = mockClipboard
+	return runner, mockClipboard, &out
+}
+
+func TestClipboardOutput(t *testing.T) {
+	runner, mockClipboard, out := buildOutputRunner("project\n└── main.go\n", nil)
+	mockClipboard.On("CopyText", "project\n└── main.go\n").Return(nil)
+
+	assert.NoError(t, runner.Run(nil))
+	assert.Empty(t, out.String(), "output goes to the clipboard instead of stdout")
+	assert.Equal(t, out, runner.ctx.Out, "stdout is restored")
+	mockClipboard.AssertExpectations(t)
+}
+
+func TestClipboardOutputOnFailure(t *testing.T) {
+	// diff prints the differences and then fails
+	runner, mockClipboard, _ := buildOutputRunner("-main.go\n", errors.New("does not match"))
+	mockClipboard.On("CopyText", "-main.go\n").Return(nil)
+	assert.EqualError(t, runner.Run(nil), "does not match")
+	mockClipboard.AssertExpectations(t)
+
+	runner, mockClipboard, _ = buildOutputRunner("", errors.New("unable to parse"))
+	assert.EqualError(t, runner.Run(nil), "unable to parse")
+	mockClipboard.AssertNotCalled(t, "CopyText")
+
+	runner, mockClipboard, _ = buildOutputRunner("tree\n", nil)
+	mockClipboard.On("CopyText", "tree\n").Return(errors.New("no clipboard provider could copy"))
+	assert.EqualError(t, runner.Run(nil), "unable to copy to the clipboard: no clipboard provider could copy")
+}
+
+func TestWithoutClipboardOutput(t *testing.T) {
+	inner := &printer{}
+	assert.Same(t, inner, WithClipboardOutput(&ctx.SeedContext{}, inner))
+}
@@ -0,0 +1,307 @@
+package runner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/config"
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/lint"
+	"github.com/jpwallace22/seed/pkg/clipboard"
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+const (
+	msgSuccess = "Your directory tree has grown successfully!"
+)
+
+// planter parses a seed and plants it in the working directory
+type planter interface {
+	Plant(ctx context.Context, tree string) (*seed.Report, error)
+	// PlantStream plants while the seed is still being read
+	PlantStream(ctx context.Context, r io.Reader) (*seed.Report, error)
+}
+
+type seedPlanter struct {
+	format   seed.Format
+	logger   logger.Logger
+	rollback bool
+	jobs     int
+	// paths lists every planted path in the report
+	paths bool
+	// manifest records the planting for seed undo
+	manifest bool
+	conflict seed.Conflict
+	// preview asks for confirmation before planting, nil when not interactive
+	preview *preview
+	trust   *hookTrust
+	// vars are the template variables of hooks
+	vars    map[string]string
+	hookOut io.Writer
+	// git is set by --git
+	git *seed.Git
+	// linter checks the tree before anything else, nil with nothing to check or with --no-lint
+	linter *lint.Linter
+}
+
+func newSeedPlanter(ctx *ctx.SeedContext, format seed.Format) *seedPlanter {
+	p := &seedPlanter{
+		format:   format,
+		logger:   ctx.Logger,
+		rollback: ctx.Flags.Plant.Rollback,
+		jobs:     ctx.Flags.Plant.Jobs,
+		paths:    ctx.Flags.Plant.Report != "" || ctx.Flags.Plant.ReportFile != "",
+		manifest: !ctx.Flags.Plant.NoManifest,
+		conflict: seed.Conflict(ctx.Flags.Plant.Conflict),
+		vars:     ctx.Flags.Template.Vars,
+		hookOut:  ctx.Cobra.ErrOrStderr(),
+	}
+	if actions := ctx.Flags.Plant.Git; len(actions) > 0 {
+		p.git = &seed.Git{
+			Init:    actions.Has(flags.Git.Init),
+			Add:     actions.Has(flags.Git.Add),
+			Commit:  actions.Has(flags.Git.Commit),
+			Message: ctx.Flags.Plant.GitMessage,
+		}
+	}
+
+	if lintFlags := ctx.Flags.Lint; !ctx.Flags.Plant.NoLint {
+		// the rules were checked when the config was loaded
+		if linter, err := lint.New(lintFlags.Rules, lintFlags.Options()...); err == nil && !linter.Empty() {
+			p.linter = linter
+		}
+	}
+
+	out := ctx.Cobra.ErrOrStderr()
+	p.trust = &hookTrust{
+		disabled: ctx.Flags.Plant.NoHooks,
+		trusted:  ctx.Flags.Plant.TrustHooks,
+		out:      out,
+		store:    config.TrustFile(),
+	}
+	if !isTerminal(os.Stdin) {
+		return p
+	}
+
+	// the preview and the trust prompt share stdin, and so its buffer
+	in := bufio.NewReader(os.Stdin)
+	p.trust.in = in
+	// a streamed seed is planted before it has been read in full, so there is
+	// nothing to preview
+	if !ctx.Flags.Plant.Yes && !ctx.Flags.Plant.Stream {
+		p.preview = &preview{
+			in:       in,
+			out:      out,
+			color:    logger.ColorEnabled(out),
+			dest:     ".",
+			conflict: p.conflict,
+		}
+	}
+	return p
+}
+
+func (p *seedPlanter) Plant(ctx context.Context, text string) (*seed.Report, error) {
+	tree, err := seed.Parse(ctx, strings.NewReader(text), p.format)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.plantTree(ctx, tree, text)
+}
+
+// plantTree shows the preview, when interactive, and plants what was kept.
+// The hooks of the tree run once source, the seed it was parsed from, is trusted.
+func (p *seedPlanter) plantTree(ctx context.Context, tree *seed.Tree, source string) (*seed.Report, error) {
+	if err := p.lint(tree); err != nil {
+		return nil, err
+	}
+	if p.preview != nil {
+		if err := p.preview.confirm(tree); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := p.options()
+	hooks := tree.Hooks()
+	run, err := p.trust.allow(hooks, source)
+	if err != nil {
+		return nil, err
+	}
+	if run {
+		opts = append(opts, seed.WithHooks(p.vars, p.hookOut))
+	} else if len(hooks) > 0 {
+		p.logger.Warn("Skipping the %d hooks of the seed", len(hooks))
+	}
+	return seed.Plant(ctx, tree, ".", opts...)
+}
+
+// lint logs the violations of the lint rules, failing when any is an error
+func (p *seedPlanter) lint(tree *seed.Tree) error {
+	if p.linter == nil {
+		return nil
+	}
+	violations := p.linter.Check(tree.Node)
+	for _, v := range violations {
+		message := v.String()
+		if v.Line > 0 {
+			message = fmt.Sprintf("line %d: %s", v.Line, message)
+		}
+		if v.Severity == lint.Error {
+			p.logger.Error("%s", message)
+		} else {
+			p.logger.Warn("%s", message)
+		}
+	}
+	if errs, _ := lint.Count(violations); errs > 0 {
+		return fmt.Errorf("the seed has %d lint errors, fix them or plant with --no-lint", errs)
+	}
+	return nil
+}
+
+func (p *seedPlanter) PlantStream(ctx context.Context, r io.Reader) (*seed.Report, error) {
+	return seed.PlantStream(ctx, r, p.format, ".", p.options()...)
+}
+
+func (p *seedPlanter) options() []seed.PlantOption {
+	opts := []seed.PlantOption{seed.WithLogger(p.logger), seed.WithJobs(p.jobs), seed.WithConflict(p.conflict)}
+	if p.rollback {
+		opts = append(opts, seed.WithRollback())
+	}
+	if p.paths {
+		opts = append(opts, seed.WithPaths())
+	}
+	if p.manifest {
+		opts = append(opts, seed.WithManifest())
+	}
+	if p.git != nil {
+		opts = append(opts, seed.WithGitKeep(), seed.WithGit(*p.git))
+	}
+	return opts
+}
+
+type PlantRunner struct {
+	clipboard clipboard.Clipboard
+	planter   planter
+	ctx       *ctx.SeedContext
+}
+
+func NewPlantRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	format := seed.Format(ctx.Flags.Root.Format)
+	// without --format the extension of a seed file is a better hint than its content
+	if path := ctx.Flags.Root.FilePath; format == seed.FormatAuto && path != "" {
+		format = seed.DetectFormat(path, seed.FormatAuto)
+	}
+	return &PlantRunner{
+		ctx:       ctx,
+		clipboard: clipboard.New(string(ctx.Flags.Root.ClipboardProvider)),
+		planter:   newSeedPlanter(ctx, format),
+	}
+}
+
+func (r *PlantRunner) Run(args []string) error {
+	logger := r.ctx.Logger
+	flags := r.ctx.Flags.Root
+
+	if r.ctx.Flags.Plant.Stream && r.ctx.Flags.Plant.Jobs > 1 {
+		return fmt.Errorf("--stream plants in the order the seed is read and cannot be combined with --jobs")
+	}
+	if r.ctx.Flags.Plant.Stream && len(r.ctx.Flags.Plant.Git) > 0 {
+		return fmt.Errorf("--stream does not know which directories are empty and cannot be combined with --git")
+	}
+	if r.ctx.Flags.Plant.Stream && (len(r.ctx.Flags.Lint.Rules) > 0 || r.ctx.Flags.Lint.Portable) && !r.ctx.Flags.Plant.NoLint {
+		logger.Warn("The lint rules are not checked with --stream")
+	}
+	if _, err := reportFormat(r.ctx.Flags.Plant); err != nil {
+		return err
+	}
+
+	var report *seed.Report
+	var err error
+	switch {
+	case flags.FromClipboard:
+		if report, err = r.parseFromClipboard(); err != nil {
+			err = fmt.Errorf("unable to parse from clipboard: %w", err)
+		}
+
+	case flags.FilePath != "":
+		if report, err = r.parseFromFile(flags.FilePath); err != nil {
+			err = fmt.Errorf("unable to parse from file: %w", err)
+		}
+
+	case len(args) > 0:
+		logger.Log("Sprouting directories from seed: %s", args[0])
+		if report, err = r.planter.Plant(r.ctx.Context(), args[0]); err != nil {
+			err = fmt.Errorf("unable to parse the tree structure: %w", err)
+		}
+
+	default:
+		return r.ctx.Cobra.Help()
+	}
+
+	if errors.Is(err, errCancelled) {
+		return fmt.Errorf("%w, nothing was written", errCancelled)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Success(msgSuccess)
+	return writePlantReport(r.ctx.Out, r.ctx.Flags.Plant, report)
+}
+
+func (r *PlantRunner) parseFromFile(path string) (*seed.Report, error) {
+	if r.ctx.Flags.Plant.Stream {
+		return r.streamFromFile(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("file read error: %w", err)
+	}
+
+	r.ctx.Logger.Log("Sowing the seeds of " + filepath.Base(path) + "...")
+	report, err := r.planter.Plant(r.ctx.Context(), string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+	return report, nil
+}
+
+func (r *PlantRunner) streamFromFile(path string) (*seed.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("file read error: %w", err)
+	}
+	defer f.Close()
+
+	r.ctx.Logger.Log("Sowing the seeds of " + filepath.Base(path) + " as they are read...")
+	report, err := r.planter.PlantStream(r.ctx.Context(), f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+	return report, nil
+}
+
+func (r *PlantRunner) parseFromClipboard() (*seed.Report, error) {
+	text, err := r.clipboard.PasteText()
+	if err != nil {
+		return nil, fmt.Errorf("clipboard read error: %w", err)
+	}
+
+	r.ctx.Logger.Log("Planting from clipboard...")
+
+	report, err := r.planter.Plant(r.ctx.Context(), text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,269 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/internal/ctx"
+	mocklogger "github.com/jpwallace22/seed/pkg/logger/mock"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+/* ******************************************
+*               Define mocks
+* *******************************************/
+
+type MockClipboard struct {
+	mock.Mock
+}
+
+func (m *MockClipboard) CopyText(text string) error {
+	args := m.Called(text)
+	return args.Error(0)
+}
+
+func (m *MockClipboard) PasteText() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+type MockPlanter struct {
+	mock.Mock
+}
+
+func (m *MockPlanter) Plant(ctx context.Context, tree string) (*seed.Report, error) {
+	args := m.Called(tree)
+	return &seed.Report{}, args.Error(0)
+}
+
+func (m *MockPlanter) PlantStream(ctx context.Context, r io.Reader) (*seed.Report, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(string(data))
+	return &seed.Report{}, args.Error(0)
+}
+
+func buildTestRunner(testFlags flags.RootFlags) (*PlantRunner, *MockClipboard, *MockPlanter) {
+	mockLogger := mocklogger.New()
+	mockClipboard := new(MockClipboard)
+	mockPlanter := new(MockPlanter)
+	mockCmd := &cobra.Command{
+		Use: "test",
+	}
+
+	testCtx := &ctx.SeedContext{
+		Logger: mockLogger,
+		Cobra:  mockCmd,
+		Flags: flags.Flags{
+			Root: testFlags,
+		},
+	}
+
+	runner := &PlantRunner{
+		ctx:       testCtx,
+		clipboard: mockClipboard,
+		planter:   mockPlanter,
+	}
+
+	return runner, mockClipboard, mockPlanter
+}
+
+/* ******************************************
+*               Tests
+*********************************************/
+func TestClipboardOperations(t *testing.T) {
+	tests := []struct {
+		clipError     error
+		name          string
+		clipContent   string
+		errorContains string
+		flags         flags.RootFlags
+		args          []string
+		expectError   bool
+	}{
+		{
+			name: "successful clipboard parse",
+			flags: flags.RootFlags{
+				FromClipboard: true,
+			},
+			clipContent: "test-content",
+			expectError: false,
+		},
+		{
+			name: "clipboard read error",
+			flags: flags.RootFlags{
+				FromClipboard: true,
+			},
+			clipError:     errors.New("clipboard error"),
+			expectError:   true,
+			errorContains: "clipboard read error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockClipboard, mockPlanter := buildTestRunner(tt.flags)
+
+			mockClipboard.On("PasteText").Return(tt.clipContent, tt.clipError)
+			if !tt.expectError {
+				mockPlanter.On("Plant", tt.clipContent).Return(nil)
+			}
+
+			err := runner.Run(tt.args)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorContains != "" {
+					assert.Contains(t, err.Error(), tt.errorContains)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockClipboard.AssertExpectations(t)
+			mockPlanter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFileOperations(t *testing.T) {
+	tests := []struct {
+		name          string
+		filePath      string
+		fileContent   string
+		errorContains string
+		flags         flags.RootFlags
+		args          []string
+		expectError   bool
+	}{
+		{
+			name: "successful file parse",
+			flags: flags.RootFlags{
+				FilePath: "test.txt",
+			},
+			fileContent: "test content",
+			expectError: false,
+		},
+		{
+			name: "file not found",
+			flags: flags.RootFlags{
+				FilePath: "nonexistent.txt",
+			},
+			expectError:   true,
+			errorContains: "file read error",
+		},
+		{
+			name: "empty file",
+			flags: flags.RootFlags{
+				FilePath: "empty.txt",
+			},
+			expectError:   true,
+			errorContains: "file read error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, _, mockPlanter := buildTestRunner(tt.flags)
+
+			if !tt.expectError && tt.fileContent != "" {
+				err := os.WriteFile(tt.flags.FilePath, []byte(tt.fileContent), 0644)
+				defer os.Remove(tt.flags.FilePath)
+				assert.NoError(t, err)
+				mockPlanter.On("Plant", tt.fileContent).Return(nil)
+			}
+
+			err := runner.Run(tt.args)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.errorContains != "" {
+					assert.Contains(t, err.Error(), tt.errorContains)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockPlanter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStreamFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "large.seed")
+	assert.NoError(t, os.WriteFile(path, []byte("test content"), 0644))
+
+	runner, _, mockPlanter := buildTestRunner(flags.RootFlags{FilePath: path})
+	runner.ctx.Flags.Plant = flags.PlantFlags{Stream: true}
+	mockPlanter.On("PlantStream", "test content").Return(nil)
+
+	assert.NoError(t, runner.Run(nil))
+	mockPlanter.AssertExpectations(t)
+	mockPlanter.AssertNotCalled(t, "Plant", mock.Anything)
+}
+
+func TestStreamWithJobs(t *testing.T) {
+	runner, _, mockPlanter := buildTestRunner(flags.RootFlags{FilePath: "large.seed"})
+	runner.ctx.Flags.Plant = flags.PlantFlags{Stream: true, Jobs: 4}
+
+	err := runner.Run(nil)
+	assert.ErrorContains(t, err, "cannot be combined with --jobs")
+	mockPlanter.AssertExpectations(t)
+}
+
+func TestPlantReport(t *testing.T) {
+	report := &seed.Report{
+		Directories: 1,
+		Files:       2,
+		Duration:    1500 * time.Microsecond,
+		Paths: []seed.PlantedPath{
+			{Path: "p", Action: "skipped", Mode: 0755},
+			{Path: "p/run.sh", Action: "planted", IsFile: true, Mode: 0700, Bytes: 10},
+			{Path: "p/a.go", Action: "overwritten", IsFile: true, Mode: 0644, Bytes: 5},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writePlantReport(&out, flags.PlantFlags{Report: "json"}, report))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, "report", doc["type"])
+	assert.Equal(t, float64(15), doc["bytes"])
+	assert.Equal(t, float64(1), doc["planted"])
+	assert.Equal(t, float64(1), doc["skipped"])
+	assert.Equal(t, float64(1), doc["overwritten"])
+	assert.Equal(t, 1.5, doc["duration_ms"])
+	assert.Equal(t, map[string]any{
+		"path": "p/run.sh", "action": "planted", "type": "file", "mode": "0700", "bytes": float64(10),
+	}, doc["paths"].([]any)[1])
+
+	// the format follows the file extension
+	path := filepath.Join(t.TempDir(), "report.yml")
+	require.NoError(t, writePlantReport(nil, flags.PlantFlags{ReportFile: path}, report))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- path: p/run.sh\n    action: planted\n")
+
+	// nothing is written without a report flag
+	out.Reset()
+	require.NoError(t, writePlantReport(&out, flags.PlantFlags{}, report))
+	assert.Empty(t, out.String())
+
+	_, err = reportFormat(flags.PlantFlags{Report: "xml"})
+	assert.ErrorContains(t, err, "must be one of: json, yaml")
+}
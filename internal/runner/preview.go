@@ -0,0 +1,196 @@
+package runner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// errCancelled is returned when the preview is declined, nothing was planted
+var errCancelled = errors.New("planting cancelled")
+
+const (
+	colorReset  = "\033[0m"
+	colorDim    = "\033[2m"
+	colorGreen  = "\033[32m"
+	colorBlue   = "\033[34m"
+	colorYellow = "\033[33m"
+)
+
+// preview shows the parsed tree before it is planted and lets the user
+// deselect subtrees, one numbered line per node
+type preview struct {
+	in       *bufio.Reader
+	out      io.Writer
+	color    bool
+	dest     string
+	conflict seed.Conflict
+}
+
+// isTerminal reports whether f is attached to a terminal. /dev/null is a
+// character device as well, so the mode of the file is not enough.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// confirm renders t and asks until the user plants or cancels. Deselected
+// subtrees are removed from t. It returns errCancelled when nothing is to be
+// planted.
+func (p *preview) confirm(t *seed.Tree) error {
+	planned := seed.Preview(t, p.dest)
+	deselected := make(map[*tree.Node]bool)
+
+	for {
+		p.render(t, planned, deselected)
+		selected := countSelected(t.Node, deselected)
+		fmt.Fprintf(p.out, "Plant %d paths? [y/N], or numbers to deselect and reselect (e.g. 2 4-6): ", selected)
+
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			fmt.Fprintln(p.out)
+			return errCancelled
+		}
+
+		answer := strings.ToLower(strings.TrimSpace(line))
+		switch answer {
+		case "y", "yes":
+			if deselected[t.Node] || selected == 0 {
+				return errCancelled
+			}
+			prune(t.Node, deselected)
+			return nil
+		case "", "n", "no", "q":
+			return errCancelled
+		}
+
+		numbers, err := parseSelection(answer, len(planned))
+		if err != nil {
+			fmt.Fprintln(p.out, err)
+			continue
+		}
+		for _, n := range numbers {
+			node := planned[n-1].Node
+			deselected[node] = !deselected[node]
+		}
+	}
+}
+
+// render prints the tree with tree(1) connectors, each line numbered and
+// coloured by its status
+func (p *preview) render(t *seed.Tree, planned []seed.PlannedPath, deselected map[*tree.Node]bool) {
+	counts := make(map[seed.Status]int)
+	for _, path := range planned {
+		counts[path.Status]++
+	}
+	fmt.Fprintf(p.out, "%d new, %d existing, %d conflicting (%s)\n", counts[seed.StatusNew], counts[seed.StatusExisting], counts[seed.StatusConflict], p.conflict)
+
+	width := len(strconv.Itoa(len(planned)))
+	i := 0
+	var walk func(node *tree.Node, prefix, connector string, off bool)
+	walk = func(node *tree.Node, prefix, connector string, off bool) {
+		path := planned[i]
+		i++
+		off = off || deselected[node]
+
+		name := node.Name
+		if !node.IsFile && name != "." {
+			name += "/"
+		}
+		mark := " "
+		if off {
+			mark = "-"
+		}
+		status := ""
+		if path.Status != seed.StatusNew {
+			status = "  (" + string(path.Status) + ")"
+		}
+		fmt.Fprintf(p.out, "%s %*d  %s%s\n", mark, width, i, prefix+connector, p.paint(name+status, path.Status, off))
+
+		childPrefix := prefix
+		switch connector {
+		case "├── ":
+			childPrefix += "│   "
+		case "└── ":
+			childPrefix += "    "
+		}
+		for j, child := range node.Children {
+			if j == len(node.Children)-1 {
+				walk(child, childPrefix, "└── ", off)
+			} else {
+				walk(child, childPrefix, "├── ", off)
+			}
+		}
+	}
+	walk(t.Node, "", "", false)
+}
+
+func (p *preview) paint(text string, status seed.Status, off bool) string {
+	if !p.color {
+		return text
+	}
+	color := colorGreen
+	switch {
+	case off:
+		color = colorDim
+	case status == seed.StatusExisting:
+		color = colorBlue
+	case status == seed.StatusConflict:
+		color = colorYellow
+	}
+	return color + text + colorReset
+}
+
+// parseSelection reads line numbers and ranges such as "2 4-6", separated by
+// spaces or commas
+func parseSelection(answer string, max int) ([]int, error) {
+	var numbers []int
+	for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ' ' || r == ',' }) {
+		from, to, isRange := strings.Cut(field, "-")
+		if !isRange {
+			to = from
+		}
+		start, err1 := strconv.Atoi(from)
+		end, err2 := strconv.Atoi(to)
+		if err1 != nil || err2 != nil || start < 1 || end > max || start > end {
+			return nil, fmt.Errorf("%q is not a line number between 1 and %d", field, max)
+		}
+		for n := start; n <= end; n++ {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers, nil
+}
+
+// countSelected counts the nodes that are neither deselected nor below a deselected directory
+func countSelected(node *tree.Node, deselected map[*tree.Node]bool) int {
+	if deselected[node] {
+		return 0
+	}
+	count := 1
+	for _, child := range node.Children {
+		count += countSelected(child, deselected)
+	}
+	return count
+}
+
+// prune removes the deselected subtrees
+func prune(node *tree.Node, deselected map[*tree.Node]bool) {
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		if deselected[child] {
+			continue
+		}
+		prune(child, deselected)
+		kept = append(kept, child)
+	}
+	node.Children = kept
+}
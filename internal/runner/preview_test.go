@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func previewTree() *seed.Tree {
+	return seed.NewTree(tree.NewDir("app",
+		tree.NewDir("src", tree.NewFile("main.go"), tree.NewFile("util.go")),
+		tree.NewFile("README.md"),
+	))
+}
+
+func newTestPreview(t *testing.T, input string) (*preview, *bytes.Buffer) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "app", "README.md"), nil, 0644))
+
+	var out bytes.Buffer
+	return &preview{
+		in:       bufio.NewReader(strings.NewReader(input)),
+		out:      &out,
+		dest:     dest,
+		conflict: seed.ConflictOverwrite,
+	}, &out
+}
+
+func TestPreviewRender(t *testing.T) {
+	p, out := newTestPreview(t, "y\n")
+	require.NoError(t, p.confirm(previewTree()))
+
+	assert.Equal(t, `3 new, 1 existing, 1 conflicting (overwrite)
+  1  app/  (existing)
+  2  ├── src/
+  3  │   ├── main.go
+  4  │   └── util.go
+  5  └── README.md  (conflict)
+Plant 5 paths? [y/N], or numbers to deselect and reselect (e.g. 2 4-6): `, out.String())
+}
+
+func TestPreviewDeselect(t *testing.T) {
+	p, out := newTestPreview(t, "2 5\n4\n3-4\nyes\n")
+	tr := previewTree()
+	require.NoError(t, p.confirm(tr))
+
+	// src and README.md are deselected, util.go is toggled twice along the way
+	assert.Contains(t, out.String(), "- 3  │   ├── main.go\n")
+	assert.Contains(t, out.String(), "Plant 1 paths?")
+	assert.True(t, tr.Equal(tree.NewDir("app")))
+}
+
+func TestPreviewCancel(t *testing.T) {
+	for _, input := range []string{"\n", "n\n", "", "1\ny\n"} {
+		p, _ := newTestPreview(t, input)
+		tr := previewTree()
+		assert.ErrorIs(t, p.confirm(tr), errCancelled, "input %q", input)
+		assert.True(t, tr.Equal(previewTree().Node), "nothing is pruned on cancel")
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	numbers, err := parseSelection("1, 3-4 2", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 4, 2}, numbers)
+
+	for _, answer := range []string{"0", "6", "4-2", "maybe"} {
+		_, err := parseSelection(answer, 5)
+		assert.ErrorContains(t, err, "is not a line number between 1 and 5", answer)
+	}
+}
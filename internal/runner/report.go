@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/cmd/flags"
+	"github.com/jpwallace22/seed/pkg/logger"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"gopkg.in/yaml.v3"
+)
+
+// plantReport is the document written by --report, the planting counterpart of
+// the `tree -J` report the JSON parser reads
+type plantReport struct {
+	Type        string       `json:"type" yaml:"type"`
+	Paths       []reportPath `json:"paths" yaml:"paths"`
+	Directories int          `json:"directories" yaml:"directories"`
+	Files       int          `json:"files" yaml:"files"`
+	Bytes       int64        `json:"bytes" yaml:"bytes"`
+	Planted     int          `json:"planted" yaml:"planted"`
+	Skipped     int          `json:"skipped" yaml:"skipped"`
+	Overwritten int          `json:"overwritten" yaml:"overwritten"`
+	DurationMS  float64      `json:"duration_ms" yaml:"duration_ms"`
+}
+
+type reportPath struct {
+	Path   string `json:"path" yaml:"path"`
+	Action string `json:"action" yaml:"action"`
+	Type   string `json:"type" yaml:"type"`
+	Mode   string `json:"mode" yaml:"mode"`
+	Bytes  int64  `json:"bytes" yaml:"bytes"`
+}
+
+func newPlantReport(report *seed.Report) plantReport {
+	doc := plantReport{
+		Type:        "report",
+		Paths:       make([]reportPath, 0, len(report.Paths)),
+		Directories: report.Directories,
+		Files:       report.Files,
+		DurationMS:  float64(report.Duration.Microseconds()) / 1000,
+	}
+
+	for _, p := range report.Paths {
+		kind := "directory"
+		if p.IsFile {
+			kind = "file"
+		}
+		doc.Paths = append(doc.Paths, reportPath{
+			Path:   p.Path,
+			Action: p.Action,
+			Type:   kind,
+			Mode:   fmt.Sprintf("%04o", p.Mode.Perm()),
+			Bytes:  p.Bytes,
+		})
+
+		doc.Bytes += p.Bytes
+		switch p.Action {
+		case logger.EventPlanted:
+			doc.Planted++
+		case logger.EventSkipped:
+			doc.Skipped++
+		case logger.EventOverwritten:
+			doc.Overwritten++
+		}
+	}
+	return doc
+}
+
+// reportFormat is the --report format, taken from the --report-file extension
+// when only a file is given. It is empty when no report was asked for.
+func reportFormat(f flags.PlantFlags) (string, error) {
+	format := f.Report
+	if format == "" && f.ReportFile != "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(f.ReportFile)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+
+	if format != "" && format != "json" && format != "yaml" {
+		return "", fmt.Errorf("invalid report %q, must be one of: json, yaml", format)
+	}
+	return format, nil
+}
+
+// writePlantReport writes the report asked for with --report to stdout or --report-file
+func writePlantReport(out io.Writer, f flags.PlantFlags, report *seed.Report) error {
+	format, err := reportFormat(f)
+	if err != nil || format == "" {
+		return err
+	}
+
+	if f.ReportFile != "" {
+		file, err := os.Create(f.ReportFile)
+		if err != nil {
+			return fmt.Errorf("unable to write the report: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	doc := newPlantReport(report)
+	if format == "yaml" {
+		encoder := yaml.NewEncoder(out)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("unable to write the report: %w", err)
+		}
+		return encoder.Close()
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("unable to write the report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jpwallace22/seed/internal/config"
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type TemplateRunner struct {
+	ctx *ctx.SeedContext
+}
+
+func NewTemplateRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &TemplateRunner{ctx: ctx}
+}
+
+func (r *TemplateRunner) Run(args []string) error {
+	dirs := r.ctx.Flags.Template.Dirs
+	if len(dirs) == 0 {
+		dirs = []string{config.DefaultTemplateDir()}
+	}
+
+	if len(args) == 0 {
+		return r.list(dirs)
+	}
+	return r.plant(dirs, args[0])
+}
+
+// list prints the templates of every directory. A name found in several
+// directories is listed once, as only the first one can be planted.
+func (r *TemplateRunner) list(dirs []string) error {
+	seen := make(map[string]bool)
+	read := 0
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) && len(dirs) > 1 {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read template directory %s: %w", dir, err)
+		}
+		read++
+
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			if entry.IsDir() || seen[name] {
+				continue
+			}
+			seen[name] = true
+			fmt.Fprintln(r.ctx.Out, name)
+		}
+	}
+
+	if read == 0 {
+		return fmt.Errorf("none of the template directories exist: %s", strings.Join(dirs, ", "))
+	}
+	return nil
+}
+
+func (r *TemplateRunner) plant(dirs []string, name string) error {
+	if _, err := reportFormat(r.ctx.Flags.Plant); err != nil {
+		return err
+	}
+
+	path, err := r.find(dirs, name)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("file read error: %w", err)
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid template %s: %w", name, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, r.ctx.Flags.Template.Vars); err != nil {
+		return fmt.Errorf("unable to render template %s: %w", name, err)
+	}
+
+	tree, err := seed.Parse(r.ctx.Context(), strings.NewReader(rendered.String()), seed.DetectFormat(path, seed.FormatTree))
+	if err != nil {
+		return fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+
+	r.ctx.Logger.Log("Planting template %s...", name)
+	planter := newSeedPlanter(r.ctx, seed.FormatAuto)
+	if trustedTemplate(path, r.ctx.Flags.Template.TrustedDirs) {
+		planter.trust.trusted = true
+	}
+	report, err := planter.plantTree(r.ctx.Context(), tree, rendered.String())
+	if errors.Is(err, errCancelled) {
+		return fmt.Errorf("%w, nothing was written", errCancelled)
+	}
+	if err != nil {
+		return err
+	}
+	r.ctx.Logger.Success(msgSuccess)
+	return writePlantReport(r.ctx.Out, r.ctx.Flags.Plant, report)
+}
+
+// trustedTemplate reports whether the template at path was installed by the
+// user, in a directory of the user config or the default one
+func trustedTemplate(path string, trusted []string) bool {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, d := range trusted {
+		if abs, err := filepath.Abs(d); err == nil && abs == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// find matches the template by name, with or without its extension, in the
+// first directory that has it
+func (r *TemplateRunner) find(dirs []string, name string) (string, error) {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) && len(dirs) > 1 {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("unable to read template directory %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			if entry.Name() == name || strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())) == name {
+				return filepath.Join(dir, entry.Name()), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("template %q not found in %s", name, strings.Join(dirs, ", "))
+}
@@ -0,0 +1,53 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type UndoRunner struct {
+	ctx *ctx.SeedContext
+}
+
+func NewUndoRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &UndoRunner{ctx: ctx}
+}
+
+func (r *UndoRunner) Run(args []string) error {
+	var manifest string
+	if len(args) > 0 {
+		manifest = args[0]
+	} else {
+		latest, err := seed.LatestManifest(".")
+		if err != nil {
+			return err
+		}
+		manifest = latest
+	}
+
+	var opts []seed.UndoOption
+	if r.ctx.Flags.Undo.Force {
+		opts = append(opts, seed.WithForce())
+	}
+
+	report, err := seed.Undo(manifest, opts...)
+	var modified *seed.ModifiedError
+	if errors.As(err, &modified) {
+		return fmt.Errorf("%w, use --force to undo anyway", err)
+	}
+	if report != nil {
+		for _, path := range report.Kept {
+			r.ctx.Logger.Warn("Kept %s, it holds files that were not planted", path)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to undo the planting: %w", err)
+	}
+
+	r.ctx.Logger.Success("Removed %d planted paths and restored %d files", len(report.Removed), len(report.Restored))
+	return nil
+}
@@ -0,0 +1,42 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type ValidateRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+func NewValidateRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &ValidateRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *ValidateRunner) Run(args []string) error {
+	text, err := r.input.read(args)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	tree, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+
+	dirs, files := tree.Counts()
+	r.ctx.Logger.Success("Seed is valid: %d directories, %d files", dirs, files)
+	return nil
+}
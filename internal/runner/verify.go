@@ -0,0 +1,110 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jpwallace22/seed/internal/ctx"
+	"github.com/jpwallace22/seed/internal/verify"
+	"github.com/jpwallace22/seed/pkg/seed"
+	"github.com/spf13/cobra"
+)
+
+type VerifyRunner struct {
+	input seedInput
+	ctx   *ctx.SeedContext
+}
+
+type verifyReport struct {
+	Dir     string          `json:"dir"`
+	Results []verify.Result `json:"results"`
+	Passed  int             `json:"passed"`
+	Failed  int             `json:"failed"`
+	Skipped int             `json:"skipped"`
+}
+
+func NewVerifyRunner(cobra *cobra.Command, ctx *ctx.SeedContext) Runner {
+	return &VerifyRunner{
+		ctx:   ctx,
+		input: newSeedInput(ctx),
+	}
+}
+
+func (r *VerifyRunner) Run(args []string) error {
+	flags := r.ctx.Flags.Verify
+	if flags.Output != "text" && flags.Output != "json" {
+		return fmt.Errorf("invalid output %q, must be one of: text, json", flags.Output)
+	}
+
+	text, dir, err := r.input.readWithDir(args)
+	if errors.Is(err, errNoInput) {
+		return r.ctx.Cobra.Help()
+	}
+	if err != nil {
+		return err
+	}
+
+	expected, err := seed.Parse(r.ctx.Context(), strings.NewReader(text), seed.Format(r.ctx.Flags.Root.Format))
+	if err != nil {
+		return fmt.Errorf("unable to parse the tree structure: %w", err)
+	}
+
+	opts := ignoreOptions(r.ctx.Flags.Ignore)
+	harvested, err := seed.Harvest(dir, append(opts, seed.WithHidden())...)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+	// rules for what the harvest leaves out are not checked, as diff does not compare them
+	if err := expected.Filter(dir, opts...); err != nil {
+		return fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+
+	report := verifyReport{
+		Dir:     dir,
+		Results: verify.Check(expected.Paths(), harvested.Paths()),
+	}
+	for _, result := range report.Results {
+		switch result.Status {
+		case verify.Pass:
+			report.Passed++
+		case verify.Fail:
+			report.Failed++
+		case verify.Skip:
+			report.Skipped++
+		}
+	}
+
+	if flags.Output == "json" {
+		encoder := json.NewEncoder(r.ctx.Out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		r.writeText(report)
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d rules failed verification", report.Failed, len(report.Results))
+	}
+	r.ctx.Logger.Success("%s satisfies the seed", dir)
+	return nil
+}
+
+func (r *VerifyRunner) writeText(report verifyReport) {
+	symbols := map[verify.Status]string{
+		verify.Pass: "✓",
+		verify.Fail: "✗",
+		verify.Skip: "-",
+	}
+
+	for _, result := range report.Results {
+		line := fmt.Sprintf("%s %s (%s)", symbols[result.Status], result.Rule, result.Kind)
+		if result.Message != "" {
+			line += ": " + result.Message
+		}
+		fmt.Fprintln(r.ctx.Out, line)
+	}
+}
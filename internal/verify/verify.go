@@ -0,0 +1,191 @@
+package verify
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type Kind string
+
+const (
+	// Required nodes must exist under every directory their parent matches
+	Required Kind = "required"
+	// Optional nodes are written as `name?` and only need the right type when present
+	Optional Kind = "optional"
+	// Forbidden nodes are written as `!name` and must not exist
+	Forbidden Kind = "forbidden"
+	// OneOf nodes are written as `{a,b}` and need at least one alternative to exist
+	OneOf Kind = "one-of"
+)
+
+type Status string
+
+const (
+	Pass Status = "pass"
+	Fail Status = "fail"
+	// Skip is used when nothing matches the parent, usually because it already failed
+	Skip Status = "skip"
+)
+
+type Result struct {
+	Rule    string   `json:"rule"`
+	Kind    Kind     `json:"kind"`
+	Status  Status   `json:"status"`
+	Matches []string `json:"matches,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+type rule struct {
+	kind     Kind
+	patterns []string
+}
+
+// Check evaluates every node of the seed as a rule against the actual paths.
+// Both sides are path sets as returned by tree.Node.Paths, where node names may
+// be glob patterns such as `internal/*/*.go`.
+func Check(seed, actual map[string]bool) []Result {
+	rules := make([]string, 0, len(seed))
+	for p := range seed {
+		rules = append(rules, p)
+	}
+	sort.Strings(rules)
+
+	children := index(actual)
+	results := make([]Result, 0, len(rules))
+
+	for _, p := range rules {
+		parts := strings.Split(p, "/")
+		r := parseRule(parts[len(parts)-1])
+		result := Result{Rule: p, Kind: r.kind, Status: Pass}
+
+		parents, ok := matchParents(parts[:len(parts)-1], children, actual)
+		if !ok || len(parents) == 0 {
+			result.Status = Skip
+			result.Message = "no directory matches the parent"
+			results = append(results, result)
+			continue
+		}
+
+		var failures []string
+		for _, parent := range parents {
+			matches, wrongType := matchChildren(parent, r, seed[p], children, actual)
+			result.Matches = append(result.Matches, matches...)
+
+			switch {
+			case r.kind == Forbidden && len(matches) > 0:
+				failures = append(failures, strings.Join(matches, ", ")+" must not exist")
+			case r.kind == Forbidden:
+			case len(wrongType) > 0:
+				failures = append(failures, strings.Join(wrongType, ", ")+" should be a "+tree.KindOf(seed[p]))
+			case len(matches) == 0 && r.kind != Optional:
+				failures = append(failures, "nothing in "+display(parent)+" matches")
+			}
+		}
+
+		if len(failures) > 0 {
+			result.Status = Fail
+			result.Message = strings.Join(failures, "; ")
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func parseRule(name string) rule {
+	switch {
+	case strings.HasPrefix(name, "!"):
+		return rule{kind: Forbidden, patterns: []string{name[1:]}}
+	case strings.HasSuffix(name, "?"):
+		return rule{kind: Optional, patterns: []string{strings.TrimSuffix(name, "?")}}
+	case strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}"):
+		return rule{kind: OneOf, patterns: strings.Split(name[1:len(name)-1], ",")}
+	}
+	return rule{kind: Required, patterns: []string{name}}
+}
+
+// matchParents expands the parent components into every actual directory they
+// match, files having nothing below them. A forbidden parent has nothing to
+// check below it.
+func matchParents(parts []string, children map[string][]string, actual map[string]bool) ([]string, bool) {
+	current := []string{""}
+	for _, part := range parts {
+		r := parseRule(part)
+		if r.kind == Forbidden {
+			return nil, false
+		}
+
+		var next []string
+		for _, dir := range current {
+			for _, child := range children[dir] {
+				if !actual[child] && matchAny(r.patterns, path.Base(child)) {
+					next = append(next, child)
+				}
+			}
+		}
+		current = next
+	}
+	return current, true
+}
+
+// matchChildren finds the entries under parent that the rule matches, and which
+// of those have the wrong type. Glob patterns only match entries of the rule's
+// type, `*.go` saying nothing about directories, while a plain name matches
+// whatever it is so that the wrong type can be reported.
+func matchChildren(parent string, r rule, isFile bool, children map[string][]string, actual map[string]bool) (matches, wrongType []string) {
+	var names, globs []string
+	for _, pattern := range r.patterns {
+		if strings.ContainsAny(pattern, `*?[\`) {
+			globs = append(globs, pattern)
+		} else {
+			names = append(names, pattern)
+		}
+	}
+
+	for _, child := range children[parent] {
+		name := path.Base(child)
+		if !matchAny(names, name) && (actual[child] != isFile || !matchAny(globs, name)) {
+			continue
+		}
+		matches = append(matches, child)
+		if actual[child] != isFile {
+			wrongType = append(wrongType, child)
+		}
+	}
+	return matches, wrongType
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.TrimSpace(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// index groups the actual paths by their parent directory, "" being the root
+func index(actual map[string]bool) map[string][]string {
+	children := make(map[string][]string)
+	for p := range actual {
+		parent := path.Dir(p)
+		if parent == "." {
+			parent = ""
+		}
+		children[parent] = append(children[parent], p)
+	}
+	for _, list := range children {
+		sort.Strings(list)
+	}
+	return children
+}
+
+func display(dir string) string {
+	if dir == "" {
+		return "the root"
+	}
+	return dir
+}
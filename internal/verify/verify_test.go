@@ -0,0 +1,93 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	actual := map[string]bool{
+		"internal":          false,
+		"internal/foo":      false,
+		"internal/foo/a.go": true,
+		"internal/bar":      false,
+		"internal/bar/b.md": true,
+		"internal/doc.go":   true,
+		"docs":              true,
+		"go.mod":            true,
+		".env":              true,
+	}
+
+	tests := []struct {
+		name     string
+		seed     map[string]bool
+		expected []Result
+	}{
+		{
+			name: "required nodes",
+			seed: map[string]bool{"internal": false, "cmd": false},
+			expected: []Result{
+				{Rule: "cmd", Kind: Required, Status: Fail, Message: "nothing in the root matches"},
+				{Rule: "internal", Kind: Required, Status: Pass, Matches: []string{"internal"}},
+			},
+		},
+		{
+			name: "optional nodes only check the type",
+			seed: map[string]bool{"CHANGELOG.md?": true, "docs?": false},
+			expected: []Result{
+				{Rule: "CHANGELOG.md?", Kind: Optional, Status: Pass},
+				{Rule: "docs?", Kind: Optional, Status: Fail, Matches: []string{"docs"}, Message: "docs should be a directory"},
+			},
+		},
+		{
+			name: "forbidden nodes",
+			seed: map[string]bool{"!.env": true, "!vendor": false},
+			expected: []Result{
+				{Rule: "!.env", Kind: Forbidden, Status: Fail, Matches: []string{".env"}, Message: ".env must not exist"},
+				{Rule: "!vendor", Kind: Forbidden, Status: Pass},
+			},
+		},
+		{
+			name: "one of",
+			seed: map[string]bool{"{go.mod,package.json}": true, "{Makefile,justfile}": true},
+			expected: []Result{
+				{Rule: "{Makefile,justfile}", Kind: OneOf, Status: Fail, Message: "nothing in the root matches"},
+				{Rule: "{go.mod,package.json}", Kind: OneOf, Status: Pass, Matches: []string{"go.mod"}},
+			},
+		},
+		{
+			name: "wildcards apply to every matching parent",
+			seed: map[string]bool{"internal": false, "internal/*": false, "internal/*/*.go": true},
+			expected: []Result{
+				{Rule: "internal", Kind: Required, Status: Pass, Matches: []string{"internal"}},
+				{Rule: "internal/*", Kind: Required, Status: Pass, Matches: []string{"internal/bar", "internal/foo"}},
+				{Rule: "internal/*/*.go", Kind: Required, Status: Fail, Matches: []string{"internal/foo/a.go"}, Message: "nothing in internal/bar matches"},
+			},
+		},
+		{
+			name: "wildcards only match their own type",
+			seed: map[string]bool{"internal/*.go": true, "*": false, "internal/!*.go": false, "internal/?oo": true},
+			expected: []Result{
+				{Rule: "*", Kind: Required, Status: Pass, Matches: []string{"internal"}},
+				{Rule: "internal/!*.go", Kind: Forbidden, Status: Pass},
+				{Rule: "internal/*.go", Kind: Required, Status: Pass, Matches: []string{"internal/doc.go"}},
+				{Rule: "internal/?oo", Kind: Required, Status: Fail, Message: "nothing in internal matches"},
+			},
+		},
+		{
+			name: "children of a missing parent are skipped",
+			seed: map[string]bool{"cmd": false, "cmd/main.go": true},
+			expected: []Result{
+				{Rule: "cmd", Kind: Required, Status: Fail, Message: "nothing in the root matches"},
+				{Rule: "cmd/main.go", Kind: Required, Status: Skip, Message: "no directory matches the parent"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Check(tt.seed, actual))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package clipboard reads and writes the system clipboard through whichever
+// provider works in the current environment: the Wayland and X11 command line
+// tools, tmux buffers, the terminal itself over OSC 52, or a plain file.
+package clipboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Clipboard copies text to and pastes text from a clipboard.
+type Clipboard interface {
+	CopyText(text string) error
+	PasteText() (string, error)
+}
+
+// Provider is a Clipboard that may only work in some environments.
+type Provider interface {
+	Clipboard
+	Name() string
+	// Detect returns why the provider cannot be used here, or nil when it can
+	Detect() error
+}
+
+// Auto is the provider name that tries every provider in turn.
+const Auto = "auto"
+
+// Providers lists the built-in providers in the order Auto tries them. The
+// file provider comes first so tests and CI can pin the clipboard with
+// SEED_CLIPBOARD_FILE. The system clipboard of the platform is preferred over
+// the tmux paste buffer, and OSC 52 comes last as many terminals do not answer it.
+func Providers() []Provider {
+	return []Provider{
+		NewFile(""),
+		wayland,
+		xclip,
+		xsel,
+		macOS,
+		windows,
+		termux,
+		tmux,
+		NewOSC52(),
+	}
+}
+
+// Names lists the values accepted by New.
+func Names() []string {
+	names := []string{Auto}
+	for _, p := range Providers() {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+// New returns the named provider, or one trying every provider when name is
+// empty or Auto. An unknown name is reported by the first copy or paste, so
+// callers that cannot fail on construction can still surface it.
+func New(name string) Clipboard {
+	if name == "" || name == Auto {
+		return &auto{providers: Providers()}
+	}
+	for _, p := range Providers() {
+		if p.Name() == name {
+			return &single{provider: p}
+		}
+	}
+	return unknown(name)
+}
+
+// Lookup reports whether New accepts name.
+func Lookup(name string) bool {
+	for _, candidate := range Names() {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Attempt is a provider that was tried and the reason it failed.
+type Attempt struct {
+	Provider string
+	Err      error
+}
+
+// TriedError is returned when no provider could copy or paste. It lists every
+// provider that was tried.
+type TriedError struct {
+	Op       string
+	Attempts []Attempt
+}
+
+func (e *TriedError) Error() string {
+	tried := make([]string, 0, len(e.Attempts))
+	for _, a := range e.Attempts {
+		tried = append(tried, fmt.Sprintf("%s (%v)", a.Provider, a.Err))
+	}
+	return fmt.Sprintf("no clipboard provider could %s, tried: %s", e.Op, strings.Join(tried, ", "))
+}
+
+func (e *TriedError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Attempts))
+	for _, a := range e.Attempts {
+		errs = append(errs, a.Err)
+	}
+	return errs
+}
+
+// auto uses the first provider that is detected and succeeds
+type auto struct {
+	providers []Provider
+}
+
+func (a *auto) CopyText(text string) error {
+	_, err := a.try("copy", func(p Provider) (string, error) {
+		return "", p.CopyText(text)
+	})
+	return err
+}
+
+func (a *auto) PasteText() (string, error) {
+	return a.try("paste", func(p Provider) (string, error) {
+		return p.PasteText()
+	})
+}
+
+func (a *auto) try(op string, fn func(Provider) (string, error)) (string, error) {
+	tried := &TriedError{Op: op}
+	for _, p := range a.providers {
+		if err := p.Detect(); err != nil {
+			tried.Attempts = append(tried.Attempts, Attempt{Provider: p.Name(), Err: err})
+			continue
+		}
+		text, err := fn(p)
+		if err == nil {
+			return text, nil
+		}
+		tried.Attempts = append(tried.Attempts, Attempt{Provider: p.Name(), Err: err})
+	}
+	return "", tried
+}
+
+// single uses one provider, saying why when it does not fit the environment
+type single struct {
+	provider Provider
+}
+
+func (s *single) CopyText(text string) error {
+	if err := s.detect(); err != nil {
+		return err
+	}
+	return s.provider.CopyText(text)
+}
+
+func (s *single) PasteText() (string, error) {
+	if err := s.detect(); err != nil {
+		return "", err
+	}
+	return s.provider.PasteText()
+}
+
+func (s *single) detect() error {
+	if err := s.provider.Detect(); err != nil {
+		return fmt.Errorf("clipboard provider %s is not available: %w", s.provider.Name(), err)
+	}
+	return nil
+}
+
+type unknown string
+
+func (u unknown) CopyText(string) error {
+	return u.err()
+}
+
+func (u unknown) PasteText() (string, error) {
+	return "", u.err()
+}
+
+func (u unknown) err() error {
+	return fmt.Errorf("unknown clipboard provider %q, must be one of: %s", string(u), strings.Join(Names(), ", "))
+}
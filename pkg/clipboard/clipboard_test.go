@@ -0,0 +1,147 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fake is a provider with canned results
+type fake struct {
+	name   string
+	detect error
+	text   string
+	err    error
+}
+
+func (f *fake) Name() string               { return f.name }
+func (f *fake) Detect() error              { return f.detect }
+func (f *fake) CopyText(text string) error { f.text = text; return f.err }
+func (f *fake) PasteText() (string, error) { return f.text, f.err }
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clipboard")
+	c := NewFile(path)
+
+	require.NoError(t, c.CopyText("project\n└── main.go\n"))
+	text, err := c.PasteText()
+	require.NoError(t, err)
+	assert.Equal(t, "project\n└── main.go\n", text)
+}
+
+func TestFileFromEnv(t *testing.T) {
+	t.Setenv(FileEnv, "")
+	assert.ErrorContains(t, NewFile("").Detect(), "SEED_CLIPBOARD_FILE is not set")
+
+	path := filepath.Join(t.TempDir(), "clipboard")
+	require.NoError(t, os.WriteFile(path, []byte("seed"), 0600))
+	t.Setenv(FileEnv, path)
+
+	text, err := New(Auto).PasteText()
+	require.NoError(t, err)
+	assert.Equal(t, "seed", text, "auto detection tries the file provider first")
+}
+
+func TestAutoFallsBack(t *testing.T) {
+	working := &fake{name: "working", text: "seed"}
+	a := &auto{providers: []Provider{
+		&fake{name: "absent", detect: errors.New("DISPLAY is not set")},
+		&fake{name: "broken", err: errors.New("exit status 1")},
+		working,
+	}}
+
+	text, err := a.PasteText()
+	require.NoError(t, err)
+	assert.Equal(t, "seed", text)
+
+	require.NoError(t, a.CopyText("copied"))
+	assert.Equal(t, "copied", working.text)
+}
+
+func TestAutoListsProvidersTried(t *testing.T) {
+	broken := errors.New("exit status 1")
+	a := &auto{providers: []Provider{
+		&fake{name: "absent", detect: errors.New("DISPLAY is not set")},
+		&fake{name: "broken", err: broken},
+	}}
+
+	_, err := a.PasteText()
+	assert.EqualError(t, err, "no clipboard provider could paste, tried: absent (DISPLAY is not set), broken (exit status 1)")
+	assert.ErrorIs(t, err, broken)
+
+	var tried *TriedError
+	require.ErrorAs(t, a.CopyText("x"), &tried)
+	assert.Equal(t, "copy", tried.Op)
+	assert.Len(t, tried.Attempts, 2)
+}
+
+func TestProvidersOrder(t *testing.T) {
+	names := Names()[1:]
+	index := func(name string) int {
+		for i, n := range names {
+			if n == name {
+				return i
+			}
+		}
+		t.Fatalf("no provider %s", name)
+		return -1
+	}
+	// inside tmux on macOS or Windows, the system clipboard is the one meant
+	assert.Less(t, index("macos"), index("tmux"))
+	assert.Less(t, index("windows"), index("tmux"))
+	assert.Less(t, index("tmux"), index("osc52"))
+}
+
+func TestNew(t *testing.T) {
+	assert.True(t, Lookup("xclip"))
+	assert.True(t, Lookup(Auto))
+	assert.False(t, Lookup("clippy"))
+
+	_, err := New("clippy").PasteText()
+	assert.ErrorContains(t, err, `unknown clipboard provider "clippy", must be one of: auto, file,`)
+
+	t.Setenv("DISPLAY", "")
+	_, err = New("xsel").PasteText()
+	assert.EqualError(t, err, "clipboard provider xsel is not available: DISPLAY is not set")
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as a fake xclip")
+	}
+
+	dir := t.TempDir()
+	store := filepath.Join(dir, "store")
+	script := "#!/bin/sh\nif [ \"$1\" = -in ]; then cat > " + store + "; else cat " + store + "; fi\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("DISPLAY", ":0")
+
+	c := New("xclip")
+	require.NoError(t, c.CopyText("seed"))
+	text, err := c.PasteText()
+	require.NoError(t, err)
+	assert.Equal(t, "seed", text)
+
+	require.NoError(t, os.Remove(store))
+	_, err = c.PasteText()
+	assert.ErrorContains(t, err, "xclip: exit status 1: cat:")
+}
+
+func TestDecodeOSC52(t *testing.T) {
+	text, err := decodeOSC52([]byte("\x1b]52;c;c2VlZA==\a"))
+	require.NoError(t, err)
+	assert.Equal(t, "seed", text)
+
+	text, err = decodeOSC52([]byte("\x1b]52;c;c2VlZA==\x1b\\"))
+	require.NoError(t, err)
+	assert.Equal(t, "seed", text)
+
+	_, err = decodeOSC52([]byte("\x1b[?1;2c"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// command is a provider backed by a pair of command line tools
+type command struct {
+	name  string
+	copy  []string
+	paste []string
+	// env are environment variables that must be set for the tools to reach the clipboard
+	env  []string
+	goos string
+}
+
+var (
+	wayland = &command{
+		name:  "wayland",
+		copy:  []string{"wl-copy"},
+		paste: []string{"wl-paste", "--no-newline"},
+		env:   []string{"WAYLAND_DISPLAY"},
+	}
+	xclip = &command{
+		name:  "xclip",
+		copy:  []string{"xclip", "-in", "-selection", "clipboard"},
+		paste: []string{"xclip", "-out", "-selection", "clipboard"},
+		env:   []string{"DISPLAY"},
+	}
+	xsel = &command{
+		name:  "xsel",
+		copy:  []string{"xsel", "--input", "--clipboard"},
+		paste: []string{"xsel", "--output", "--clipboard"},
+		env:   []string{"DISPLAY"},
+	}
+	// tmux keeps its own paste buffers, which work over SSH and without a display
+	tmux = &command{
+		name:  "tmux",
+		copy:  []string{"tmux", "load-buffer", "-"},
+		paste: []string{"tmux", "save-buffer", "-"},
+		env:   []string{"TMUX"},
+	}
+	macOS = &command{
+		name:  "macos",
+		copy:  []string{"pbcopy"},
+		paste: []string{"pbpaste"},
+		goos:  "darwin",
+	}
+	windows = &command{
+		name:  "windows",
+		copy:  []string{"clip.exe"},
+		paste: []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"},
+		goos:  "windows",
+	}
+	termux = &command{
+		name:  "termux",
+		copy:  []string{"termux-clipboard-set"},
+		paste: []string{"termux-clipboard-get"},
+	}
+)
+
+func (c *command) Name() string {
+	return c.name
+}
+
+func (c *command) Detect() error {
+	if c.goos != "" && runtime.GOOS != c.goos {
+		return fmt.Errorf("only available on %s", c.goos)
+	}
+	for _, env := range c.env {
+		if os.Getenv(env) == "" {
+			return fmt.Errorf("%s is not set", env)
+		}
+	}
+	for _, tool := range []string{c.copy[0], c.paste[0]} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return fmt.Errorf("%s not found", tool)
+		}
+	}
+	return nil
+}
+
+func (c *command) CopyText(text string) error {
+	_, err := run(c.copy, text)
+	return err
+}
+
+func (c *command) PasteText() (string, error) {
+	return run(c.paste, "")
+}
+
+// run executes args with stdin, returning stdout. Failures carry stderr, which
+// is where the tools explain themselves.
+func run(args []string, stdin string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("%s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
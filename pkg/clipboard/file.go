@@ -0,0 +1,56 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+)
+
+// FileEnv names the file the file provider uses when none is given to NewFile.
+const FileEnv = "SEED_CLIPBOARD_FILE"
+
+// File is a clipboard kept in a plain file, meant for tests and CI.
+type File struct {
+	path string
+}
+
+// NewFile keeps the clipboard in path. An empty path means the file named by
+// SEED_CLIPBOARD_FILE, looked up on every use.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) Name() string {
+	return "file"
+}
+
+func (f *File) Detect() error {
+	if f.file() == "" {
+		return fmt.Errorf("%s is not set", FileEnv)
+	}
+	return nil
+}
+
+func (f *File) CopyText(text string) error {
+	if err := f.Detect(); err != nil {
+		return err
+	}
+	return os.WriteFile(f.file(), []byte(text), 0600)
+}
+
+func (f *File) PasteText() (string, error) {
+	if err := f.Detect(); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(f.file())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (f *File) file() string {
+	if f.path != "" {
+		return f.path
+	}
+	return os.Getenv(FileEnv)
+}
@@ -0,0 +1,124 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	osc52Timeout = 2 * time.Second
+	// osc52Limit bounds the terminal's answer, terminals cap what they send anyway
+	osc52Limit = 8 * 1024 * 1024
+)
+
+// OSC52 talks to the terminal itself with the OSC 52 escape sequence, which
+// reaches the local clipboard over SSH. Pasting needs a terminal that answers
+// clipboard queries, which many disable by default.
+type OSC52 struct {
+	tty     string
+	timeout time.Duration
+}
+
+func NewOSC52() *OSC52 {
+	return &OSC52{tty: "/dev/tty", timeout: osc52Timeout}
+}
+
+func (o *OSC52) Name() string {
+	return "osc52"
+}
+
+func (o *OSC52) Detect() error {
+	if name := os.Getenv("TERM"); name == "" || name == "dumb" {
+		return errors.New("no capable terminal")
+	}
+	f, err := os.OpenFile(o.tty, os.O_RDWR, 0)
+	if err != nil {
+		return errors.New("no terminal")
+	}
+	return f.Close()
+}
+
+func (o *OSC52) CopyText(text string) error {
+	tty, err := os.OpenFile(o.tty, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(wrapTmux("\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"))
+	return err
+}
+
+func (o *OSC52) PasteText() (string, error) {
+	tty, err := os.OpenFile(o.tty, os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	// the answer has to be read without the terminal echoing or line buffering it
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	if _, err := tty.WriteString(wrapTmux("\x1b]52;c;?\a")); err != nil {
+		return "", err
+	}
+	if err := tty.SetReadDeadline(time.Now().Add(o.timeout)); err != nil {
+		return "", err
+	}
+
+	var answer []byte
+	buf := make([]byte, 4096)
+	for len(answer) < osc52Limit {
+		n, err := tty.Read(buf)
+		answer = append(answer, buf[:n]...)
+		if bytes.HasSuffix(answer, []byte("\a")) || bytes.HasSuffix(answer, []byte("\x1b\\")) {
+			return decodeOSC52(answer)
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return "", errors.New("the terminal did not answer the clipboard query")
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", errors.New("the terminal answer is too long")
+}
+
+// decodeOSC52 extracts the text from an `ESC ] 52 ; c ; base64 BEL` answer,
+// which may also be terminated by `ESC \`
+func decodeOSC52(answer []byte) (string, error) {
+	s := strings.TrimSuffix(strings.TrimSuffix(string(answer), "\a"), "\x1b\\")
+	start := strings.Index(s, "\x1b]52;")
+	if start < 0 {
+		return "", errors.New("unexpected answer from the terminal")
+	}
+	_, data, ok := strings.Cut(s[start+len("\x1b]52;"):], ";")
+	if !ok {
+		return "", errors.New("unexpected answer from the terminal")
+	}
+
+	text, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("unexpected answer from the terminal: %w", err)
+	}
+	return string(text), nil
+}
+
+// wrapTmux passes seq through tmux to the outer terminal when running inside tmux
+func wrapTmux(seq string) string {
+	if os.Getenv("TMUX") == "" {
+		return seq
+	}
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
@@ -0,0 +1,207 @@
+// Package formats is the registry of seed formats. Every parser and writer,
+// including the built-in ones, registers itself here by name, file extension
+// and content sniffing function, so a new format can be added by importing a
+// package that calls Register from its init function.
+package formats
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Parser builds a tree from its text representation without touching the
+// filesystem. Parsers stop with ctx.Err() once ctx is done.
+type Parser interface {
+	Parse(ctx context.Context, input string) (*tree.Node, error)
+}
+
+// EmitFunc receives the nodes of a streamed tree in depth-first order, each
+// one only after its parent. dir is the slash separated path of the parent,
+// starting with the root name, and is empty for the root itself. Emitted nodes
+// never have children, those are emitted separately.
+type EmitFunc func(dir string, node *tree.Node) error
+
+// StreamParser is implemented by parsers that can read very large inputs in
+// bounded memory, emitting nodes as they are read instead of building the tree.
+type StreamParser interface {
+	Parser
+	ParseStream(ctx context.Context, r io.Reader, emit EmitFunc) error
+}
+
+// Writer renders a tree in a format.
+type Writer interface {
+	Write(io.Writer, *tree.Node) error
+}
+
+// WriteOptions are passed to every writer. Writers ignore options that do not apply to them.
+type WriteOptions struct {
+	// ASCII asks tree-like writers for `tree --charset=ascii` connectors
+	ASCII bool
+	// Indent is the indentation width of nested formats such as JSON and YAML,
+	// the format's own default when zero
+	Indent int
+}
+
+// Format describes a registered format. A format may be input only, output
+// only or both, depending on which constructors it provides.
+type Format struct {
+	Name string
+	// Extensions are matched case-insensitively and include the dot, e.g. ".json"
+	Extensions []string
+	// Sniff reports whether the content looks like this format. Formats without
+	// one are never detected from content.
+	Sniff     func(data []byte) bool
+	NewParser func() Parser
+	NewWriter func(WriteOptions) Writer
+}
+
+// CanParse reports whether the format can be used as input.
+func (f Format) CanParse() bool {
+	return f.NewParser != nil
+}
+
+// CanWrite reports whether the format can be used as output.
+func (f Format) CanWrite() bool {
+	return f.NewWriter != nil
+}
+
+// Finder discovers a format that was not registered ahead of time, such as an
+// executable plugin on $PATH. It is only consulted by Lookup.
+type Finder func(name string) (Format, bool)
+
+var (
+	mu       sync.RWMutex
+	registry []Format
+	finders  []Finder
+)
+
+// Register adds a format. Like database/sql drivers, registering the same name
+// twice or a format without a name panics, since it can only be a programming error.
+func Register(f Format) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if f.Name == "" {
+		panic("formats: Register called without a name")
+	}
+	if !f.CanParse() && !f.CanWrite() {
+		panic(fmt.Sprintf("formats: %s has neither a parser nor a writer", f.Name))
+	}
+	for _, existing := range registry {
+		if existing.Name == f.Name {
+			panic(fmt.Sprintf("formats: Register called twice for %s", f.Name))
+		}
+	}
+	registry = append(registry, f)
+}
+
+// RegisterFinder adds a Finder. Finders run in registration order after the
+// registered formats have been checked.
+func RegisterFinder(find Finder) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	finders = append(finders, find)
+}
+
+// Lookup finds a format by name, falling back to the registered finders.
+func Lookup(name string) (Format, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, f := range registry {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	for _, find := range finders {
+		if f, ok := find(name); ok {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// All returns the registered formats in registration order. Formats that are
+// only reachable through a Finder are not listed.
+func All() []Format {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return append([]Format(nil), registry...)
+}
+
+// Names lists the registered format names in registration order. Passing a
+// filter such as Format.CanParse restricts the list.
+func Names(filter ...func(Format) bool) []string {
+	var names []string
+	for _, f := range All() {
+		if matches(f, filter) {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+func matches(f Format, filters []func(Format) bool) bool {
+	for _, filter := range filters {
+		if !filter(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// ByExtension finds the format registered for the extension of path.
+func ByExtension(path string) (Format, bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == "" {
+		return Format{}, false
+	}
+
+	for _, f := range All() {
+		for _, candidate := range f.Extensions {
+			if strings.ToLower(candidate) == ext {
+				return f, true
+			}
+		}
+	}
+	return Format{}, false
+}
+
+// Sniff returns the first parsable format, in registration order, whose sniffing
+// function accepts the content.
+func Sniff(data []byte) (Format, bool) {
+	for _, f := range All() {
+		if f.Sniff != nil && f.CanParse() && f.Sniff(data) {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// NewParser looks up a format and builds its parser.
+func NewParser(name string) (Parser, error) {
+	f, ok := Lookup(name)
+	if !ok || !f.CanParse() {
+		return nil, fmt.Errorf("unsupported parser format: %s, must be one of: %s",
+			name, strings.Join(Names(Format.CanParse), ", "))
+	}
+	return f.NewParser(), nil
+}
+
+// NewWriter looks up a format and builds its writer.
+func NewWriter(name string, opts WriteOptions) (Writer, error) {
+	f, ok := Lookup(name)
+	if !ok || !f.CanWrite() {
+		return nil, fmt.Errorf("unsupported writer format: %s, must be one of: %s",
+			name, strings.Join(Names(Format.CanWrite), ", "))
+	}
+	return f.NewWriter(opts), nil
+}
@@ -0,0 +1,124 @@
+package formats
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineParser reads one path per line, enough to exercise the registry
+type lineParser struct{}
+
+func (lineParser) Parse(ctx context.Context, input string) (*tree.Node, error) {
+	root := tree.NewDir(".")
+	for _, line := range strings.Fields(input) {
+		if _, err := root.Insert(line, true); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+type countWriter struct{}
+
+func (countWriter) Write(w io.Writer, root *tree.Node) error {
+	dirs, files := root.Counts()
+	_, err := fmt.Fprintf(w, "%d %d\n", dirs, files)
+	return err
+}
+
+func init() {
+	Register(Format{
+		Name:       "lines",
+		Extensions: []string{".LINES"},
+		Sniff:      func(data []byte) bool { return bytes.HasPrefix(data, []byte("#lines")) },
+		NewParser:  func() Parser { return lineParser{} },
+	})
+	Register(Format{
+		Name:      "count",
+		NewWriter: func(WriteOptions) Writer { return countWriter{} },
+	})
+}
+
+func TestLookup(t *testing.T) {
+	f, ok := Lookup("lines")
+	require.True(t, ok)
+	assert.True(t, f.CanParse())
+	assert.False(t, f.CanWrite())
+
+	_, ok = Lookup("missing")
+	assert.False(t, ok)
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{"lines", "count"}, Names())
+	assert.Equal(t, []string{"lines"}, Names(Format.CanParse))
+	assert.Equal(t, []string{"count"}, Names(Format.CanWrite))
+}
+
+func TestByExtension(t *testing.T) {
+	f, ok := ByExtension("dir/layout.lines")
+	require.True(t, ok)
+	assert.Equal(t, "lines", f.Name)
+
+	_, ok = ByExtension("layout")
+	assert.False(t, ok)
+}
+
+func TestSniff(t *testing.T) {
+	f, ok := Sniff([]byte("#lines\na/b"))
+	require.True(t, ok)
+	assert.Equal(t, "lines", f.Name)
+
+	_, ok = Sniff([]byte("a/b"))
+	assert.False(t, ok)
+}
+
+func TestNewParserAndWriter(t *testing.T) {
+	p, err := NewParser("lines")
+	require.NoError(t, err)
+	root, err := p.Parse(context.Background(), "a/b c")
+	require.NoError(t, err)
+
+	w, err := NewWriter("count", WriteOptions{})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, w.Write(&out, root))
+	assert.Equal(t, "2 2\n", out.String())
+
+	_, err = NewParser("count")
+	assert.ErrorContains(t, err, "must be one of: lines")
+	_, err = NewWriter("lines", WriteOptions{})
+	assert.ErrorContains(t, err, "must be one of: count")
+}
+
+func TestRegisterPanics(t *testing.T) {
+	assert.Panics(t, func() { Register(Format{Name: "lines", NewParser: func() Parser { return lineParser{} }}) })
+	assert.Panics(t, func() { Register(Format{NewParser: func() Parser { return lineParser{} }}) })
+	assert.Panics(t, func() { Register(Format{Name: "empty"}) })
+}
+
+func TestFinder(t *testing.T) {
+	RegisterFinder(func(name string) (Format, bool) {
+		if name != "found" && name != "lines" {
+			return Format{}, false
+		}
+		return Format{Name: name, NewParser: func() Parser { return lineParser{} }}, true
+	})
+
+	f, ok := Lookup("found")
+	require.True(t, ok)
+	assert.Equal(t, "found", f.Name)
+	assert.NotContains(t, Names(), "found", "found formats are not listed")
+
+	f, ok = Lookup("lines")
+	require.True(t, ok)
+	assert.NotNil(t, f.Sniff, "registered formats win over finders")
+}
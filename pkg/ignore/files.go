@@ -0,0 +1,127 @@
+package ignore
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Ignore files read in every directory, the second taking precedence
+const (
+	GitIgnore  = ".gitignore"
+	SeedIgnore = ".seedignore"
+)
+
+// Load returns a matcher for paths relative to dir with the patterns git
+// would apply there. Inside a repository these are, from lowest to highest
+// precedence, the global core.excludesFile, .git/info/exclude and the ignore
+// files of every directory from the top of the repository down to dir. The
+// .git directory itself is always ignored. Ignore files further down are
+// read with LoadDir as the walk reaches them.
+func Load(dir string) (*Matcher, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Matcher{root: abs}
+	m.add("", []string{".git"})
+	top := findRepo(abs)
+	if top == "" {
+		return m, m.LoadDir("")
+	}
+
+	if global := globalExcludes(top); global != "" {
+		if err := m.addFile("", global); err != nil {
+			return nil, err
+		}
+	}
+	// worktrees and submodules have a .git file and no info/exclude of their own
+	if info, err := os.Stat(filepath.Join(top, ".git")); err == nil && info.IsDir() {
+		if err := m.addFile("", filepath.Join(top, ".git", "info", "exclude")); err != nil {
+			return nil, err
+		}
+	}
+
+	rel, err := filepath.Rel(top, abs)
+	if err != nil {
+		return nil, err
+	}
+	m.root = top
+	if rel != "." {
+		m.prefix = filepath.ToSlash(rel)
+	}
+
+	// the ignore files of the directories above dir, top first
+	base := ""
+	if err := m.loadAt(base); err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(m.prefix, "/") {
+		if name == "" {
+			continue
+		}
+		base = join(base, name)
+		if err := m.loadAt(base); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// LoadDir adds the ignore files of dir, a slash separated directory relative
+// to the directory being matched. It does nothing for a matcher made by New.
+func (m *Matcher) LoadDir(dir string) error {
+	if m.root == "" {
+		return nil
+	}
+	return m.loadAt(join(m.prefix, dir))
+}
+
+// loadAt adds the ignore files of base, relative to the top
+func (m *Matcher) loadAt(base string) error {
+	dir := filepath.Join(m.root, filepath.FromSlash(base))
+	for _, name := range []string{GitIgnore, SeedIgnore} {
+		if err := m.addFile(base, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findRepo returns the top of the git repository containing dir, or "" when
+// there is none
+func findRepo(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// globalExcludes is the core.excludesFile of the git config, which defaults
+// to git/ignore in the XDG config directory
+func globalExcludes(repo string) string {
+	if _, err := exec.LookPath("git"); err == nil {
+		cmd := exec.Command("git", "config", "--path", "--get", "core.excludesFile")
+		cmd.Dir = repo
+		if out, err := cmd.Output(); err == nil {
+			if path := strings.TrimSpace(string(out)); path != "" {
+				return path
+			}
+		}
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+// Package ignore matches paths against gitignore patterns, with negation,
+// anchoring, directory-only patterns and ** wildcards, and loads the ignore
+// files git would read for a directory.
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+type pattern struct {
+	// base is the directory the pattern was read in, relative to the top of
+	// the matcher, "" for the top itself
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Matcher reports whether paths are ignored. Later patterns take precedence
+// over earlier ones, as they do in a .gitignore.
+type Matcher struct {
+	// root is the absolute top directory ignore files are read from, empty
+	// when the matcher only holds patterns it was given
+	root string
+	// prefix is the directory paths are relative to, relative to the top
+	prefix   string
+	patterns []pattern
+}
+
+// New returns a matcher for the given patterns, relative to the directory
+// being matched.
+func New(patterns ...string) *Matcher {
+	m := &Matcher{}
+	m.Add("", patterns...)
+	return m
+}
+
+// Add appends patterns read in base, a slash separated directory relative to
+// the directory being matched. Blank lines and comments are skipped.
+func (m *Matcher) Add(base string, patterns ...string) {
+	m.add(join(m.prefix, base), patterns)
+}
+
+func (m *Matcher) add(base string, lines []string) {
+	for _, line := range lines {
+		if p, ok := parse(line); ok {
+			p.base = base
+			m.patterns = append(m.patterns, p)
+		}
+	}
+}
+
+// addFile appends the patterns of the file at path, read in base relative to
+// the top. A missing file has no patterns.
+func (m *Matcher) addFile(base, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lines, err := readLines(f)
+	if err != nil {
+		return err
+	}
+	m.add(base, lines)
+	return nil
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// Match reports whether the slash separated path, relative to the directory
+// being matched, is ignored. Paths inside an ignored directory are not
+// reported by Match, callers are expected to skip the whole directory.
+func (m *Matcher) Match(name string, isDir bool) bool {
+	full := join(m.prefix, name)
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		p := m.patterns[i]
+		if p.dirOnly && !isDir {
+			continue
+		}
+		rel := full
+		if p.base != "" {
+			if !strings.HasPrefix(full, p.base+"/") {
+				continue
+			}
+			rel = full[len(p.base)+1:]
+		}
+		if p.re.MatchString(rel) {
+			return !p.negate
+		}
+	}
+	return false
+}
+
+// Empty reports whether the matcher has no patterns.
+func (m *Matcher) Empty() bool {
+	return len(m.patterns) == 0
+}
+
+// parse reads a line of a .gitignore, reporting false for blank lines and comments
+func parse(line string) (pattern, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" || line[0] == '#' {
+		return pattern{}, false
+	}
+	// trailing spaces are dropped unless escaped with a backslash
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	var p pattern
+	switch {
+	case line[0] == '!':
+		p.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") && !strings.HasSuffix(line, `\/`) {
+		p.dirOnly = true
+		line = line[:len(line)-1]
+	}
+	if line == "" {
+		return pattern{}, false
+	}
+
+	// a slash anywhere but at the end anchors the pattern to its directory,
+	// otherwise it matches a name at any depth
+	expr := globRegexp(strings.TrimPrefix(line, "/"))
+	if strings.Contains(line, "/") {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return pattern{}, false
+	}
+	p.re = re
+	return p, true
+}
+
+// globRegexp translates a gitignore glob to a regular expression. Wildcards
+// never match a slash, except for a ** that makes up a whole path component.
+func globRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); {
+		c := glob[i]
+		switch {
+		case c == '*':
+			j := i
+			for j < len(glob) && glob[j] == '*' {
+				j++
+			}
+			whole := j-i == 2 && (i == 0 || glob[i-1] == '/') && (j == len(glob) || glob[j] == '/')
+			switch {
+			case whole && j == len(glob):
+				// a trailing ** matches everything inside
+				b.WriteString(".*")
+			case whole:
+				// a leading or middle **/ matches any number of directories
+				b.WriteString("(?:.*/)?")
+				j++
+			default:
+				b.WriteString("[^/]*")
+			}
+			i = j
+			continue
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			if class, n := bracket(glob[i:]); n > 0 {
+				b.WriteString(class)
+				i += n
+				continue
+			}
+			b.WriteString(`\[`)
+		case c == '\\' && i+1 < len(glob):
+			b.WriteString(regexp.QuoteMeta(glob[i+1 : i+2]))
+			i += 2
+			continue
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+		i++
+	}
+	return b.String()
+}
+
+// bracket translates the [...] expression at the start of glob, returning
+// its length or 0 when it is not closed
+func bracket(glob string) (string, int) {
+	i := 1
+	negate := i < len(glob) && (glob[i] == '!' || glob[i] == '^')
+	if negate {
+		i++
+	}
+	start := i
+	// a ] right after the opening bracket is part of the set
+	if i < len(glob) && glob[i] == ']' {
+		i++
+	}
+	for i < len(glob) && glob[i] != ']' {
+		if glob[i] == '\\' {
+			i++
+		}
+		i++
+	}
+	if i >= len(glob) {
+		return "", 0
+	}
+
+	var set strings.Builder
+	for j := start; j < i; j++ {
+		switch c := glob[j]; {
+		case c == '\\' && j+1 < i:
+			j++
+			set.WriteString(regexp.QuoteMeta(glob[j : j+1]))
+		case c == '[' || c == ']' || c == '^':
+			set.WriteString(`\` + string(c))
+		default:
+			set.WriteByte(c)
+		}
+	}
+	if negate {
+		// a negated set still never matches a slash
+		return "[^/" + set.String() + "]", i + 1
+	}
+	return "[" + set.String() + "]", i + 1
+}
+
+func join(dir, name string) string {
+	switch {
+	case dir == "":
+		return name
+	case name == "":
+		return dir
+	}
+	return path.Join(dir, name)
+}
@@ -0,0 +1,117 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.log", "debug.log", false, true},
+		{"*.log", "logs/debug.log", false, true},
+		{"*.log", "debug.logs", false, false},
+		{"node_modules", "web/node_modules", true, true},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"build/", "src/build", true, true},
+		{"/build", "build", true, true},
+		{"/build", "src/build", true, false},
+		{"doc/*.txt", "doc/notes.txt", false, true},
+		{"doc/*.txt", "doc/server/arch.txt", false, false},
+		{"doc/*.txt", "src/doc/notes.txt", false, false},
+		{"**/logs", "a/b/logs", true, true},
+		{"**/logs", "logs", true, true},
+		{"logs/**", "logs/a/b.log", false, true},
+		{"a/**/b", "a/b", true, true},
+		{"a/**/b", "a/x/y/b", true, true},
+		{"a/**/b", "ab", true, false},
+		{"foo**bar", "foo/bar", false, false},
+		{"?.go", "a.go", false, true},
+		{"?.go", "ab.go", false, false},
+		{"[abc].go", "b.go", false, true},
+		{"[!abc].go", "d.go", false, true},
+		{"[!abc].go", "a.go", false, false},
+		{"[a-c]*", "cat", false, true},
+		{`\#notes`, "#notes", false, true},
+		{`\!important`, "!important", false, true},
+		{`trailing\ `, "trailing ", false, true},
+		{"trailing  ", "trailing", false, true},
+		{"# comment", "# comment", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, New(tt.pattern).Match(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestNegation(t *testing.T) {
+	m := New("*.log", "!keep.log")
+	assert.True(t, m.Match("debug.log", false))
+	assert.False(t, m.Match("keep.log", false))
+
+	// the last matching pattern wins
+	m = New("!keep.log", "*.log")
+	assert.True(t, m.Match("keep.log", false))
+
+	// patterns read in a directory only apply below it
+	m = New("*.log")
+	m.Add("src", "!*.log", "/gen")
+	assert.False(t, m.Match("src/a.log", false))
+	assert.True(t, m.Match("a.log", false))
+	assert.True(t, m.Match("src/gen", true))
+	assert.False(t, m.Match("src/pkg/gen", true))
+	assert.False(t, m.Match("gen", true))
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	require.NoError(t, os.MkdirAll(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "git", "ignore"), []byte("*.swp\n"), 0644))
+
+	repo := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(repo, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	write(".git/info/exclude", "secret\n")
+	write(".gitignore", "*.o\n/dist\n")
+	write("app/.gitignore", "!keep.o\n")
+	write("app/.seedignore", "fixtures/\n")
+	write("app/web/.gitignore", "node_modules\n")
+
+	m, err := Load(filepath.Join(repo, "app"))
+	require.NoError(t, err)
+	assert.True(t, m.Match(".git", true))
+	assert.True(t, m.Match("main.o", false), "from the parent .gitignore")
+	assert.False(t, m.Match("keep.o", false), "negated in app")
+	assert.False(t, m.Match("dist", true), "anchored to the top of the repository")
+	assert.True(t, m.Match("secret", false), "from .git/info/exclude")
+	assert.True(t, m.Match("fixtures", true), "from .seedignore")
+	assert.True(t, m.Match("main.go.swp", false), "from the global excludes")
+
+	assert.False(t, m.Match("web/node_modules", true), "not loaded yet")
+	require.NoError(t, m.LoadDir("web"))
+	assert.True(t, m.Match("web/node_modules", true))
+	assert.False(t, m.Match("node_modules", true))
+
+	// outside of a repository only the ignore files of the directory apply
+	plain := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(plain, ".gitignore"), []byte("tmp/\n"), 0644))
+	m, err = Load(plain)
+	require.NoError(t, err)
+	assert.True(t, m.Match("tmp", true))
+	assert.False(t, m.Match("x.swp", false))
+}
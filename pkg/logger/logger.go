@@ -1,8 +1,16 @@
+// Package logger is the leveled logger used by seed, backed by log/slog. Text
+// output is meant for people and only coloured on a terminal, JSON output is
+// meant for tools and carries the node event fields below.
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
+	"os"
+
+	"golang.org/x/term"
 )
 
 type Logger interface {
@@ -11,46 +19,160 @@ type Logger interface {
 	Error(format string, v ...interface{})
 	Success(format string, v ...interface{})
 	Log(format string, v ...interface{})
+	// Node reports what happened to a single planted path, see the Event constants
+	Node(event, path string, isFile bool)
+}
+
+// Node events and their field names. They are part of the JSON log format and
+// do not change, so CI dashboards can rely on them.
+const (
+	EventPlanted     = "planted"
+	EventSkipped     = "skipped"
+	EventOverwritten = "overwritten"
+
+	KeyEvent = "event"
+	KeyPath  = "path"
+	KeyType  = "type"
+)
+
+// LevelSuccess sits between info and warn, so it is shown wherever info is.
+const LevelSuccess = slog.LevelInfo + 2
+
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+type Options struct {
+	// Level is the minimum level written, slog.LevelInfo by default
+	Level slog.Leveler
+	// Format is FormatText by default
+	Format Format
+	// Color enables ANSI colours in the text format, see ColorEnabled
+	Color bool
+}
+
+// SlogLogger implements Logger on top of a slog.Logger.
+type SlogLogger struct {
+	slog *slog.Logger
+}
+
+// New writes log records to w.
+func New(w io.Writer, opts Options) *SlogLogger {
+	if opts.Level == nil {
+		opts.Level = slog.LevelInfo
+	}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level:       opts.Level,
+			ReplaceAttr: replaceLevel,
+		})
+	default:
+		handler = newTextHandler(w, opts.Level, opts.Color)
+	}
+	return FromSlog(slog.New(handler))
 }
 
-type SimpleLogger struct {
-	output io.Writer
-	errOut io.Writer
-	silent bool
+// FromSlog logs through an existing slog.Logger, so seed can be embedded in a
+// program that already has its own handler.
+func FromSlog(l *slog.Logger) *SlogLogger {
+	return &SlogLogger{slog: l}
 }
 
-func NewLogger(output, errOut io.Writer, silent bool) *SimpleLogger {
-	if silent {
-		output = io.Discard
+// Discard drops everything, it is the default wherever a logger is optional.
+func Discard() *SlogLogger {
+	return FromSlog(slog.New(discardHandler{}))
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// ColorEnabled reports whether w is a terminal that should get colours. The
+// NO_COLOR convention and TERM=dumb turn colours off.
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
 	}
-	return &SimpleLogger{
-		output: output,
-		errOut: errOut,
-		silent: silent,
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
 	}
+	return term.IsTerminal(int(f.Fd()))
 }
 
-func (l *SimpleLogger) log(writer io.Writer, color, format string, v ...interface{}) {
-	coloredFormat := fmt.Sprintf("%s%s%s", color, format, "\033[0m")
-	fmt.Fprintf(writer, coloredFormat+"\n", v...)
+func (l *SlogLogger) logf(level slog.Level, format string, v ...interface{}) {
+	ctx := context.Background()
+	if !l.slog.Enabled(ctx, level) {
+		return
+	}
+	l.slog.Log(ctx, level, fmt.Sprintf(format, v...))
 }
 
-func (l *SimpleLogger) Info(format string, v ...interface{}) {
-	l.log(l.output, "\033[34m", format, v...)
+func (l *SlogLogger) Info(format string, v ...interface{}) {
+	l.logf(slog.LevelInfo, format, v...)
 }
 
-func (l *SimpleLogger) Warn(format string, v ...interface{}) {
-	l.log(l.output, "\033[33m", format, v...)
+func (l *SlogLogger) Warn(format string, v ...interface{}) {
+	l.logf(slog.LevelWarn, format, v...)
 }
 
-func (l *SimpleLogger) Error(format string, v ...interface{}) {
-	l.log(l.errOut, "\033[31m", format, v...)
+func (l *SlogLogger) Error(format string, v ...interface{}) {
+	l.logf(slog.LevelError, format, v...)
 }
 
-func (l *SimpleLogger) Success(format string, v ...interface{}) {
-	l.log(l.output, "\033[32m", format, v...)
+func (l *SlogLogger) Success(format string, v ...interface{}) {
+	l.logf(LevelSuccess, format, v...)
 }
 
-func (l *SimpleLogger) Log(format string, v ...interface{}) {
-	l.log(l.output, "", format, v...)
+func (l *SlogLogger) Log(format string, v ...interface{}) {
+	l.logf(slog.LevelInfo, format, v...)
+}
+
+// Node logs overwritten files as warnings and everything else as info.
+func (l *SlogLogger) Node(event, path string, isFile bool) {
+	level := slog.LevelInfo
+	if event == EventOverwritten {
+		level = slog.LevelWarn
+	}
+
+	ctx := context.Background()
+	if !l.slog.Enabled(ctx, level) {
+		return
+	}
+
+	kind := "directory"
+	if isFile {
+		kind = "file"
+	}
+	msg := fmt.Sprintf("%s %s: %s", nodeVerbs[event], kind, path)
+	l.slog.LogAttrs(ctx, level, msg,
+		slog.String(KeyEvent, event),
+		slog.String(KeyPath, path),
+		slog.String(KeyType, kind),
+	)
+}
+
+var nodeVerbs = map[string]string{
+	EventPlanted:     "Planted",
+	EventSkipped:     "Skipped existing",
+	EventOverwritten: "Overwrote",
+}
+
+// replaceLevel names LevelSuccess in JSON output instead of printing INFO+2
+func replaceLevel(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == LevelSuccess {
+			a.Value = slog.StringValue("SUCCESS")
+		}
+	}
+	return a
 }
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextFormat(t *testing.T) {
+	var out bytes.Buffer
+	l := New(&out, Options{})
+
+	l.Log("Planting %s...", "seed")
+	l.Node(EventPlanted, "p/a.go", true)
+	l.Node(EventSkipped, "p", false)
+	l.Success("done")
+
+	assert.Equal(t, "Planting seed...\nPlanted file: p/a.go\nSkipped existing directory: p\ndone\n", out.String())
+}
+
+func TestTextColor(t *testing.T) {
+	var out bytes.Buffer
+	l := New(&out, Options{Color: true})
+
+	l.Error("broken")
+	l.Log("plain")
+	assert.Equal(t, colorRed+"broken"+colorReset+"\nplain\n", out.String())
+}
+
+func TestJSONNodeFields(t *testing.T) {
+	var out bytes.Buffer
+	l := New(&out, Options{Format: FormatJSON})
+
+	l.Node(EventOverwritten, "p/a.go", true)
+	l.Success("done")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var node map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &node))
+	assert.Equal(t, "WARN", node["level"])
+	assert.Equal(t, "overwritten", node["event"])
+	assert.Equal(t, "p/a.go", node["path"])
+	assert.Equal(t, "file", node["type"])
+
+	var success map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &success))
+	assert.Equal(t, "SUCCESS", success["level"])
+	assert.Equal(t, "done", success["msg"])
+}
+
+func TestLevel(t *testing.T) {
+	var out bytes.Buffer
+	l := New(&out, Options{Level: slog.LevelWarn})
+
+	l.Info("hidden")
+	l.Node(EventPlanted, "p", false)
+	l.Success("hidden")
+	l.Node(EventOverwritten, "p/a.go", true)
+	l.Warn("shown")
+
+	assert.Equal(t, "Overwrote file: p/a.go\nshown\n", out.String())
+}
+
+func TestColorEnabled(t *testing.T) {
+	assert.False(t, ColorEnabled(&bytes.Buffer{}), "buffers are not terminals")
+
+	f, err := os.CreateTemp(t.TempDir(), "log")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, ColorEnabled(f), "regular files are not terminals")
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, ColorEnabled(os.Stderr))
+}
+
+func TestDiscard(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Discard().Node(EventPlanted, "p", false)
+		Discard().Error("nothing")
+	})
+}
@@ -21,6 +21,7 @@ func NewWithBehavior(useDefault bool) *MockLogger {
 		m.On("Error", mock.Anything, mock.Anything).Return(nil)
 		m.On("Log", mock.Anything, mock.Anything).Return(nil)
 		m.On("Success", mock.Anything, mock.Anything).Return(nil)
+		m.On("Node", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	}
 	return m
 }
@@ -36,3 +37,4 @@ func (m *MockLogger) Warn(msg string, v ...interface{})    { m.Called(msg, v) }
 func (m *MockLogger) Error(msg string, v ...interface{})   { m.Called(msg, v) }
 func (m *MockLogger) Log(msg string, v ...interface{})     { m.Called(msg, v) }
 func (m *MockLogger) Success(msg string, v ...interface{}) { m.Called(msg, v) }
+func (m *MockLogger) Node(event, path string, isFile bool) { m.Called(event, path, isFile) }
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorBlue   = "\033[34m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+)
+
+// textHandler prints one message per line for people. Node events are already
+// spelled out in their message, other attributes are appended as key=value.
+type textHandler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	level slog.Leveler
+	color bool
+	attrs []slog.Attr
+}
+
+func newTextHandler(w io.Writer, level slog.Leveler, color bool) *textHandler {
+	return &textHandler{
+		mu:    &sync.Mutex{},
+		w:     w,
+		level: level,
+		color: color,
+	}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	color := h.colorFor(r)
+	if color != "" {
+		b.WriteString(color)
+	}
+	b.WriteString(r.Message)
+
+	isNode := false
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == KeyEvent {
+			isNode = true
+			return false
+		}
+		return true
+	})
+	if !isNode {
+		for _, a := range h.attrs {
+			writeAttr(&b, a)
+		}
+		r.Attrs(func(a slog.Attr) bool {
+			writeAttr(&b, a)
+			return true
+		})
+	}
+
+	if color != "" {
+		b.WriteString(colorReset)
+	}
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func writeAttr(b *strings.Builder, a slog.Attr) {
+	b.WriteString(" ")
+	b.WriteString(a.Key)
+	b.WriteString("=")
+	b.WriteString(a.Value.String())
+}
+
+func (h *textHandler) colorFor(r slog.Record) string {
+	if !h.color {
+		return ""
+	}
+	switch {
+	case r.Level >= slog.LevelError:
+		return colorRed
+	case r.Level >= slog.LevelWarn:
+		return colorYellow
+	case r.Level >= LevelSuccess:
+		return colorGreen
+	case r.Level < slog.LevelInfo:
+		return colorGray
+	}
+
+	color := ""
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == KeyEvent {
+			color = colorBlue
+			return false
+		}
+		return true
+	})
+	return color
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup is not meaningful for one-line messages, attributes stay ungrouped
+func (h *textHandler) WithGroup(string) slog.Handler {
+	return h
+}
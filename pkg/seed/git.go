@@ -0,0 +1,197 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// GitKeep is the file planted in empty directories by WithGitKeep, since git
+// does not track directories.
+const GitKeep = ".gitkeep"
+
+// Git lists what WithGit does once the tree is planted.
+type Git struct {
+	// Init creates a repository at the planted root unless it is already
+	// inside one
+	Init bool
+	// Add stages the files the planting created, and nothing else
+	Add bool
+	// Commit commits the created files with Message, staging them first
+	Commit  bool
+	Message string
+}
+
+// WithGit runs git in the planted root once planting and the post hooks
+// succeeded. A failing git command is returned, the tree staying planted.
+func WithGit(g Git) PlantOption {
+	return func(c *plantConfig) {
+		c.git = g
+	}
+}
+
+// WithGitKeep plants a GitKeep file in every empty directory of the tree. The
+// tree itself is left as it is.
+func WithGitKeep() PlantOption {
+	return func(c *plantConfig) {
+		c.gitKeep = true
+	}
+}
+
+// withGitKeep returns a copy of t with a GitKeep file in every empty directory
+func withGitKeep(t *Tree) *Tree {
+	root := t.Node.Clone()
+	root.Visit(func(_ string, node *tree.Node) bool {
+		if !node.IsFile && len(node.Children) == 0 {
+			node.Children = append(node.Children, tree.NewFile(GitKeep))
+		}
+		return true
+	})
+	return NewTree(root)
+}
+
+// runGit applies the git options to the root planted in dest, created being
+// every path the planting created
+func (c *plantConfig) runGit(ctx context.Context, dest, root string, created []string) error {
+	g := c.git
+	if !g.Init && !g.Add && !g.Commit {
+		return nil
+	}
+	dir, err := filepath.Abs(filepath.Join(dest, filepath.FromSlash(root)))
+	if err != nil {
+		return err
+	}
+
+	if g.Init {
+		if _, err := git(ctx, dir, nil, "rev-parse", "--git-dir"); err == nil {
+			c.logger.Log("Git repository already exists, not initializing one")
+		} else {
+			if _, err := git(ctx, dir, nil, "init", "--quiet"); err != nil {
+				return err
+			}
+			c.logger.Log("Initialized a git repository in %s", dir)
+		}
+	}
+	if !g.Add && !g.Commit {
+		return nil
+	}
+
+	files, err := createdFiles(dir, created)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		c.logger.Warn("No files were created, nothing to stage")
+		return nil
+	}
+
+	// the paths go through stdin so that large trees fit
+	pathspec := []byte(strings.Join(files, "\x00"))
+	if _, err := git(ctx, dir, pathspec, "add", "--pathspec-from-file=-", "--pathspec-file-nul"); err != nil {
+		return err
+	}
+	c.logger.Log("Staged %d files", len(files))
+	if !g.Commit {
+		return nil
+	}
+
+	message := g.Message
+	if message == "" {
+		message = "Plant " + filepath.Base(dir)
+	}
+	// committing the paths alone leaves whatever else was staged out of it
+	if _, err := git(ctx, dir, pathspec, "commit", "--quiet", "-m", message, "--pathspec-from-file=-", "--pathspec-file-nul"); err != nil {
+		return err
+	}
+	c.logger.Log("Committed %d files: %s", len(files), message)
+	return nil
+}
+
+// createdFiles keeps the created paths that are files, relative to dir. Adding
+// a created directory would also stage whatever was already in it.
+func createdFiles(dir string, created []string) ([]string, error) {
+	var files []string
+	for _, path := range created {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Lstat(abs)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		files = append(files, filepath.ToSlash(rel))
+	}
+	return files, nil
+}
+
+// git runs a git command in dir, returning its output or an error carrying
+// what git printed
+func git(ctx context.Context, dir string, stdin []byte, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); msg != "" && errors.As(err, &exitErr) {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// harvestGit reads the tree of ref in the repository containing dir into
+// root, without touching the working tree
+func harvestGit(root *tree.Node, dir, ref string, cfg *harvestConfig) error {
+	out, err := git(context.Background(), dir, nil, "ls-tree", "-r", "-t", "-z", ref)
+	if err != nil {
+		return fmt.Errorf("unable to read %s at %s: %w", dir, ref, err)
+	}
+
+	for _, entry := range strings.Split(out, "\x00") {
+		// <mode> SP <type> SP <object> TAB <path>
+		meta, path, ok := strings.Cut(entry, "\t")
+		if !ok || path == "./" || path == "." {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 {
+			return fmt.Errorf("unexpected git ls-tree output: %q", entry)
+		}
+		if !cfg.hidden && hiddenPath(path) {
+			continue
+		}
+		// submodules are commits, planted as empty directories
+		isFile := fields[1] == "blob"
+		if _, err := root.Insert(path, isFile); err != nil {
+			return fmt.Errorf("unable to read %s at %s: %w", dir, ref, err)
+		}
+	}
+	return nil
+}
+
+func hiddenPath(path string) bool {
+	for _, name := range strings.Split(path, "/") {
+		if strings.HasPrefix(name, ".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireGit skips tests when git is not installed and gives commits an identity
+func requireGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(key, "seed")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(key, "seed@example.com")
+	}
+}
+
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	out, err := git(context.Background(), dir, nil, args...)
+	require.NoError(t, err)
+	return strings.TrimSpace(out)
+}
+
+func TestWithGitKeep(t *testing.T) {
+	root := tree.NewDir("app", tree.NewDir("empty"), tree.NewDir("src", tree.NewFile("main.go")))
+	kept := withGitKeep(NewTree(root))
+
+	assert.Equal(t, map[string]bool{
+		"empty":          false,
+		"empty/.gitkeep": true,
+		"src":            false,
+		"src/main.go":    true,
+	}, kept.Paths())
+	assert.Nil(t, root.Find("empty/.gitkeep"), "the planted tree is a copy")
+}
+
+func TestPlantGit(t *testing.T) {
+	requireGit(t)
+	dest := t.TempDir()
+	root := tree.NewDir("app", tree.NewDir("empty"), tree.NewFile("main.go"))
+
+	_, err := Plant(context.Background(), NewTree(root), dest,
+		WithGitKeep(), WithGit(Git{Init: true, Commit: true, Message: "Start app"}))
+	require.NoError(t, err)
+
+	dir := filepath.Join(dest, "app")
+	assert.Equal(t, "Start app", gitOutput(t, dir, "log", "--format=%s"))
+	assert.Equal(t, "empty/.gitkeep\nmain.go", gitOutput(t, dir, "ls-files"))
+
+	// only the created files are staged, not what was already there
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0644))
+	root = tree.NewDir("app", tree.NewFile("main.go"), tree.NewDir("lib", tree.NewFile("lib.go")))
+	_, err = Plant(context.Background(), NewTree(root), dest,
+		WithConflict(ConflictSkip), WithGit(Git{Init: true, Add: true}))
+	require.NoError(t, err)
+	assert.Equal(t, "A  lib/lib.go\n?? notes.txt", gitOutput(t, dir, "status", "--short"))
+}
+
+func TestHarvestGitRef(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	root := tree.NewDir(".", tree.NewDir("src", tree.NewFile("main.go")), tree.NewFile(".env"))
+	_, err := Plant(context.Background(), NewTree(root), dir, WithGit(Git{Init: true, Commit: true}))
+	require.NoError(t, err)
+
+	// the working tree changes, the commit does not
+	require.NoError(t, os.Remove(filepath.Join(dir, "src", "main.go")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.go"), nil, 0644))
+
+	harvested, err := Harvest(dir, WithGitRef("HEAD"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"src": false, "src/main.go": true}, harvested.Paths())
+
+	harvested, err = Harvest(filepath.Join(dir, "src"), WithGitRef("HEAD"), WithHidden())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"main.go": true}, harvested.Paths())
+
+	harvested, err = Harvest(dir, WithGitRef("HEAD"), WithHidden())
+	require.NoError(t, err)
+	assert.True(t, harvested.Paths()[".env"])
+
+	_, err = Harvest(dir, WithGitRef("missing"))
+	assert.ErrorContains(t, err, "at missing")
+}
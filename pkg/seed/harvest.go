@@ -0,0 +1,108 @@
+package seed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type harvestConfig struct {
+	hidden      bool
+	gitRef      string
+	ignoreFiles bool
+	exclude     []string
+	include     []string
+}
+
+type HarvestOption func(*harvestConfig)
+
+// WithHidden includes hidden files and directories, which are skipped by default.
+func WithHidden() HarvestOption {
+	return func(c *harvestConfig) {
+		c.hidden = true
+	}
+}
+
+// WithGitRef reads the directory as it is in a git tree-ish, such as a commit,
+// branch or tag, instead of from disk. The directory has to be inside a local
+// repository, which is left as it is.
+func WithGitRef(ref string) HarvestOption {
+	return func(c *harvestConfig) {
+		c.gitRef = ref
+	}
+}
+
+// Harvest reads an existing directory into a Tree, the inverse of Plant. The
+// ManifestDir written by WithManifest is always left out.
+func Harvest(dir string, opts ...HarvestOption) (*Tree, error) {
+	cfg := &harvestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	f, err := newFilter(dir, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the ignore files of %s: %w", dir, err)
+	}
+
+	root := tree.NewDir(filepath.Base(filepath.Clean(dir)))
+	if cfg.gitRef != "" {
+		if err := harvestGit(root, dir, cfg.gitRef, cfg); err != nil {
+			return nil, err
+		}
+		// ignore files are read from the working tree, not from the commit
+		if err := f.prune("", root); err != nil {
+			return nil, err
+		}
+	} else if err := harvestChildren(root, dir, "", cfg, f); err != nil {
+		return nil, err
+	}
+	f.keepIncluded("", root)
+	return NewTree(root), nil
+}
+
+// harvestChildren reads dir, which is at path relative to the harvested directory
+func harvestChildren(parent *tree.Node, dir, path string, cfg *harvestConfig, f *filter) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("unable to read directory %s: %w", dir, err)
+	}
+	if err := f.enter(path); err != nil {
+		return fmt.Errorf("unable to read the ignore files of %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if !cfg.hidden && strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		childPath := tree.Join(path, entry.Name())
+		if f.skip(childPath, entry.IsDir()) {
+			continue
+		}
+
+		node := &tree.Node{
+			Name:   entry.Name(),
+			IsFile: !entry.IsDir(),
+		}
+		parent.Children = append(parent.Children, node)
+
+		if entry.IsDir() {
+			if err := harvestChildren(node, filepath.Join(dir, entry.Name()), childPath, cfg, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
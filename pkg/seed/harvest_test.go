@@ -0,0 +1,122 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarvestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"project/src/utils", "project/.git"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	for _, f := range []string{"project/src/main.go", "project/src/utils/helper.go", "project/README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), nil, 0644))
+	}
+
+	root, err := Harvest(filepath.Join(dir, "project"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, root.Write(&out, FormatTree))
+
+	expected := `project
+├── README.md
+└── src
+    ├── main.go
+    └── utils
+        └── helper.go
+`
+	assert.Equal(t, expected, out.String())
+
+	parsed, err := Parse(context.Background(), &out, FormatTree)
+	require.NoError(t, err)
+	assert.Equal(t, root.Paths(), parsed.Paths())
+}
+
+func TestHarvestHidden(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".github", "workflows"), 0755))
+
+	root, err := Harvest(dir, WithHidden())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{".github": false, ".github/workflows": false}, root.Paths())
+
+	root, err = Harvest(dir)
+	require.NoError(t, err)
+	assert.Empty(t, root.Paths())
+}
+
+func TestHarvestManifest(t *testing.T) {
+	dir := t.TempDir()
+	planted := NewTree(tree.NewDir(".", tree.NewDir("src", tree.NewFile("main.go"))))
+	_, err := Plant(context.Background(), planted, dir, WithManifest())
+	require.NoError(t, err)
+	require.DirExists(t, filepath.Join(dir, ManifestDir))
+
+	root, err := Harvest(dir, WithHidden())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"src": false, "src/main.go": true}, root.Paths())
+}
+
+func TestHarvestMissingDir(t *testing.T) {
+	_, err := Harvest(filepath.Join(t.TempDir(), "nope"))
+	assert.Error(t, err)
+}
+
+func TestHarvestIgnore(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		".gitignore":              "node_modules/\n*.log\n!keep.log\n",
+		"web/.seedignore":         "/dist\n",
+		"web/dist/app.js":         "",
+		"web/src/dist/gen.js":     "",
+		"web/node_modules/x/x.js": "",
+		"web/debug.log":           "",
+		"web/keep.log":            "",
+		"cmd/main.go":             "",
+		"cmd/main_test.go":        "",
+	} {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	harvested, err := Harvest(dir, WithIgnoreFiles(), WithExclude("*_test.go"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"cmd":                 false,
+		"cmd/main.go":         true,
+		"web":                 false,
+		"web/src":             false,
+		"web/src/dist":        false,
+		"web/src/dist/gen.js": true,
+		"web/keep.log":        true,
+	}, harvested.Paths())
+
+	harvested, err = Harvest(dir, WithInclude("*.go", "dist/"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"cmd":                 false,
+		"cmd/main.go":         true,
+		"cmd/main_test.go":    true,
+		"web":                 false,
+		"web/dist":            false,
+		"web/dist/app.js":     true,
+		"web/src":             false,
+		"web/src/dist":        false,
+		"web/src/dist/gen.js": true,
+	}, harvested.Paths())
+
+	// a seed filtered the same way compares with the harvest
+	expected := NewTree(tree.NewDir(".", tree.NewFile("debug.log"), tree.NewDir("cmd", tree.NewFile("main.go"))))
+	require.NoError(t, expected.Filter(dir, WithIgnoreFiles()))
+	assert.Equal(t, map[string]bool{"cmd": false, "cmd/main.go": true}, expected.Paths())
+}
@@ -0,0 +1,152 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Environment variables set for every hook, on top of the current environment
+const (
+	// HookRootEnv is the absolute path of the planted root
+	HookRootEnv = "SEED_ROOT"
+	// HookPathEnv is the absolute path of the node that declared the hook
+	HookPathEnv = "SEED_PATH"
+)
+
+// HookStage is when a hook runs.
+type HookStage string
+
+const (
+	// HookPre hooks run in the destination before anything is planted
+	HookPre HookStage = "pre"
+	// HookPost hooks run once planting succeeded, in the planted root for the
+	// root's hooks and in the node's directory otherwise
+	HookPost HookStage = "post"
+)
+
+// Hook is a command declared by a node of the tree.
+type Hook struct {
+	Stage   HookStage
+	Command string
+	// Path is relative to the destination and uses forward slashes, it is
+	// empty for a root named "."
+	Path string
+	// Dir is where the command runs, relative to the destination
+	Dir string
+}
+
+// Hooks lists the hooks of every node in the order they run, the pre hooks
+// first, each stage in seed order.
+func (t *Tree) Hooks() []Hook {
+	var pre, post []Hook
+	root := t.rootPath()
+
+	t.Visit(func(path string, node *tree.Node) bool {
+		rel := root
+		if path != "" {
+			rel = tree.Join(root, path)
+		}
+		dir := rel
+		if node.IsFile {
+			dir = parentPath(rel)
+		}
+		for _, command := range node.Hooks.Pre {
+			pre = append(pre, Hook{Stage: HookPre, Command: command, Path: rel})
+		}
+		for _, command := range node.Hooks.Post {
+			post = append(post, Hook{Stage: HookPost, Command: command, Path: rel, Dir: dir})
+		}
+		return true
+	})
+	return append(pre, post...)
+}
+
+// rootPath is where the root is planted relative to the destination
+func (t *Tree) rootPath() string {
+	if t.Name == "." {
+		return ""
+	}
+	return t.Name
+}
+
+func parentPath(path string) string {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// WithHooks runs the hooks declared by the seed, which are ignored otherwise.
+// Commands are run by the shell after their {{.name}} template variables are
+// replaced from vars, with their output going to out. A failing pre hook stops
+// the planting before anything is written, a failing post hook is returned
+// once planting is done.
+func WithHooks(vars map[string]string, out io.Writer) PlantOption {
+	return func(c *plantConfig) {
+		c.hooks = true
+		c.hookVars = vars
+		c.hookOut = out
+	}
+}
+
+// renderHooks substitutes the template variables of every hook up front, so a
+// missing variable fails before anything runs
+func renderHooks(hooks []Hook, vars map[string]string) ([]Hook, error) {
+	rendered := make([]Hook, len(hooks))
+	for i, hook := range hooks {
+		tmpl, err := template.New(hook.Path).Option("missingkey=error").Parse(hook.Command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hook %q: %w", hook.Command, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, vars); err != nil {
+			return nil, fmt.Errorf("invalid hook %q: %w", hook.Command, err)
+		}
+		hook.Command = b.String()
+		rendered[i] = hook
+	}
+	return rendered, nil
+}
+
+// runHooks runs the hooks of a stage one after the other, stopping at the first failure
+func (c *plantConfig) runHooks(ctx context.Context, hooks []Hook, stage HookStage, dest, root string) error {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if hook.Stage != stage {
+			continue
+		}
+
+		c.logger.Log("Running %s hook: %s", hook.Stage, hook.Command)
+		cmd := shellCommand(ctx, hook.Command)
+		cmd.Dir = filepath.Join(dest, filepath.FromSlash(hook.Dir))
+		cmd.Env = append(os.Environ(),
+			HookRootEnv+"="+filepath.Join(dest, filepath.FromSlash(root)),
+			HookPathEnv+"="+filepath.Join(dest, filepath.FromSlash(hook.Path)),
+		)
+		cmd.Stdout = c.hookOut
+		cmd.Stderr = c.hookOut
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s hook %q failed: %w", hook.Stage, hook.Command, err)
+		}
+	}
+	return nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,92 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hookTree() *Tree {
+	main := tree.NewFile("main.go")
+	main.Hooks.Post = []string{`echo "file $(basename "$PWD") $SEED_PATH"`}
+	src := tree.NewDir("src", main)
+	src.Hooks.Pre = []string{"echo pre src"}
+	root := tree.NewDir("app", src)
+	root.Hooks.Pre = []string{`echo "pre $(ls)"`}
+	root.Hooks.Post = []string{`echo "post {{.owner}} $(basename "$PWD") $SEED_ROOT"`}
+	return NewTree(root)
+}
+
+func TestHooksOrder(t *testing.T) {
+	var got []string
+	for _, h := range hookTree().Hooks() {
+		got = append(got, string(h.Stage)+" "+h.Path+" in "+h.Dir)
+	}
+	assert.Equal(t, []string{
+		"pre app in ",
+		"pre app/src in ",
+		"post app in app",
+		"post app/src/main.go in app/src",
+	}, got)
+}
+
+func TestPlantHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are written for sh")
+	}
+	dest := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "existing"), nil, 0644))
+
+	var out bytes.Buffer
+	_, err := Plant(context.Background(), hookTree(), dest, WithHooks(map[string]string{"owner": "team"}, &out))
+	require.NoError(t, err)
+
+	root := filepath.Join(dest, "app")
+	assert.Equal(t, []string{
+		"pre existing",
+		"pre src",
+		"post team app " + root,
+		"file src " + filepath.Join(root, "src", "main.go"),
+	}, strings.Split(strings.TrimSpace(out.String()), "\n"))
+
+	// without the option the hooks are ignored
+	out.Reset()
+	_, err = Plant(context.Background(), hookTree(), t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+}
+
+func TestPlantHooksFail(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are written for sh")
+	}
+
+	// a failing pre hook plants nothing
+	dest := t.TempDir()
+	root := tree.NewDir("app", tree.NewFile("a.go"))
+	root.Hooks.Pre = []string{"exit 3"}
+	_, err := Plant(context.Background(), NewTree(root), dest, WithHooks(nil, &bytes.Buffer{}))
+	assert.ErrorContains(t, err, `pre hook "exit 3" failed`)
+	assert.NoDirExists(t, filepath.Join(dest, "app"))
+
+	// a missing variable fails before any hook runs
+	root.Hooks.Pre = []string{"touch ran"}
+	root.Hooks.Post = []string{"echo {{.missing}}"}
+	_, err = Plant(context.Background(), NewTree(root), dest, WithHooks(nil, &bytes.Buffer{}))
+	assert.ErrorContains(t, err, "invalid hook")
+	assert.NoFileExists(t, filepath.Join(dest, "ran"))
+
+	// a failing post hook is returned once the tree is planted
+	root.Hooks = tree.Hooks{Post: []string{"false"}}
+	_, err = Plant(context.Background(), NewTree(root), dest, WithHooks(nil, &bytes.Buffer{}))
+	assert.ErrorContains(t, err, `post hook "false" failed`)
+	assert.FileExists(t, filepath.Join(dest, "app", "a.go"))
+}
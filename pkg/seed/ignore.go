@@ -0,0 +1,125 @@
+package seed
+
+import (
+	"github.com/jpwallace22/seed/pkg/ignore"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// WithIgnoreFiles leaves out what git would ignore in the directory, following
+// .gitignore, .git/info/exclude and the global excludes file, as well as
+// .seedignore files, which use the same syntax. See package ignore.
+func WithIgnoreFiles() HarvestOption {
+	return func(c *harvestConfig) {
+		c.ignoreFiles = true
+	}
+}
+
+// WithExclude leaves out the paths matching any of the gitignore style patterns.
+func WithExclude(patterns ...string) HarvestOption {
+	return func(c *harvestConfig) {
+		c.exclude = append(c.exclude, patterns...)
+	}
+}
+
+// WithInclude keeps only the paths matching one of the gitignore style
+// patterns, along with the directories leading to them. A matching directory
+// is kept with everything inside it.
+func WithInclude(patterns ...string) HarvestOption {
+	return func(c *harvestConfig) {
+		c.include = append(c.include, patterns...)
+	}
+}
+
+// filter decides which paths below a directory are harvested
+type filter struct {
+	// files holds the patterns of the ignore files, nil unless WithIgnoreFiles
+	files   *ignore.Matcher
+	exclude *ignore.Matcher
+	include *ignore.Matcher
+}
+
+func newFilter(dir string, cfg *harvestConfig) (*filter, error) {
+	f := &filter{
+		// the manifests of earlier plantings are never part of the tree
+		exclude: ignore.New(append([]string{ManifestDir + "/"}, cfg.exclude...)...),
+		include: ignore.New(cfg.include...),
+	}
+	if cfg.ignoreFiles {
+		files, err := ignore.Load(dir)
+		if err != nil {
+			return nil, err
+		}
+		f.files = files
+	}
+	return f, nil
+}
+
+// enter reads the ignore files of dir, relative to the harvested directory
+func (f *filter) enter(dir string) error {
+	if f.files == nil || dir == "" {
+		return nil
+	}
+	return f.files.LoadDir(dir)
+}
+
+func (f *filter) skip(path string, isDir bool) bool {
+	return f.exclude.Match(path, isDir) || (f.files != nil && f.files.Match(path, isDir))
+}
+
+// prune applies the filter to a tree that was read in full
+func (f *filter) prune(parent string, node *tree.Node) error {
+	if err := f.enter(parent); err != nil {
+		return err
+	}
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		path := tree.Join(parent, child.Name)
+		if f.skip(path, !child.IsFile) {
+			continue
+		}
+		if !child.IsFile {
+			if err := f.prune(path, child); err != nil {
+				return err
+			}
+		}
+		kept = append(kept, child)
+	}
+	node.Children = kept
+	return nil
+}
+
+// keepIncluded drops what WithInclude does not match, reporting whether
+// anything inside node was kept
+func (f *filter) keepIncluded(parent string, node *tree.Node) bool {
+	if f.include.Empty() {
+		return true
+	}
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		path := tree.Join(parent, child.Name)
+		if f.include.Match(path, !child.IsFile) || (!child.IsFile && f.keepIncluded(path, child)) {
+			kept = append(kept, child)
+		}
+	}
+	node.Children = kept
+	return len(kept) > 0
+}
+
+// Filter removes the paths of t that harvesting dir with the same options
+// would leave out, so that the tree can be compared with the harvest. Ignore
+// files are read from dir. Hidden paths are kept.
+func (t *Tree) Filter(dir string, opts ...HarvestOption) error {
+	cfg := &harvestConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	f, err := newFilter(dir, cfg)
+	if err != nil {
+		return err
+	}
+	if err := f.prune("", t.Node); err != nil {
+		return err
+	}
+	f.keepIncluded("", t.Node)
+	return nil
+}
@@ -0,0 +1,423 @@
+package seed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jpwallace22/seed/internal/planter"
+)
+
+// ManifestDir is the directory, inside the destination, that manifests and
+// backups of overwritten files are kept in.
+const ManifestDir = ".seed"
+
+const (
+	manifestPrefix = "manifest-"
+	manifestStamp  = "20060102T150405.000000000"
+)
+
+// Manifest records what a planting changed, so that Undo can revert it. Paths
+// are relative to the directory holding ManifestDir and use forward slashes.
+type Manifest struct {
+	Time time.Time `json:"time"`
+	// Created lists the paths that did not exist before, in creation order
+	Created []ManifestPath `json:"created"`
+	// Backups lists the existing files that were overwritten
+	Backups []ManifestBackup `json:"backups"`
+}
+
+type ManifestPath struct {
+	Path string `json:"path"`
+	// Type is "file" or "directory"
+	Type string `json:"type"`
+	// SHA256 is the digest of the planted content. Files that could not be read
+	// back are recorded without one and are not checked for changes.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+type ManifestBackup struct {
+	Path string `json:"path"`
+	// Backup is where the previous content was copied to
+	Backup string `json:"backup"`
+	// SHA256 is the digest of the planted content that replaced it
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// manifestRecorder backs up overwritten files during a planting and writes the
+// manifest once it is over
+type manifestRecorder struct {
+	root  string
+	stamp string
+
+	mu      sync.Mutex
+	backups []ManifestBackup
+}
+
+func newManifestRecorder(root string, now time.Time) *manifestRecorder {
+	return &manifestRecorder{
+		root:  root,
+		stamp: now.UTC().Format(manifestStamp),
+	}
+}
+
+// backup copies path below ManifestDir before it is overwritten. It is called
+// by the planter, possibly from several workers at once.
+func (m *manifestRecorder) backup(path string) error {
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil {
+		return err
+	}
+	backup := filepath.Join(ManifestDir, "backups", m.stamp, rel)
+	if err := copyFile(path, filepath.Join(m.root, backup)); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backups = append(m.backups, ManifestBackup{
+		Path:   filepath.ToSlash(rel),
+		Backup: filepath.ToSlash(backup),
+	})
+	return nil
+}
+
+// restore puts the backed up files back, which is how a rollback undoes overwrites
+func (m *manifestRecorder) restore() error {
+	var errs []error
+	for _, b := range m.backups {
+		if err := restoreBackup(m.root, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := m.removeBackups(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (m *manifestRecorder) removeBackups() error {
+	if len(m.backups) == 0 {
+		return nil
+	}
+	if err := os.RemoveAll(filepath.Join(m.root, ManifestDir, "backups", m.stamp)); err != nil {
+		return err
+	}
+	removeEmpty(filepath.Join(m.root, ManifestDir, "backups"))
+	removeEmpty(filepath.Join(m.root, ManifestDir))
+	return nil
+}
+
+// write saves the manifest for the created paths and returns its path. Nothing
+// is written when the planting changed nothing.
+func (m *manifestRecorder) write(created []string, now time.Time) (string, error) {
+	if len(created) == 0 && len(m.backups) == 0 {
+		return "", nil
+	}
+
+	manifest := Manifest{
+		Time:    now,
+		Created: make([]ManifestPath, 0, len(created)),
+		Backups: append([]ManifestBackup{}, m.backups...),
+	}
+	for _, path := range created {
+		rel, err := filepath.Rel(m.root, path)
+		if err != nil {
+			return "", err
+		}
+		entry := ManifestPath{Path: filepath.ToSlash(rel), Type: "directory"}
+		if info, err := os.Lstat(path); err == nil && !info.IsDir() {
+			entry.Type = "file"
+			entry.SHA256, _ = digest(path)
+		}
+		manifest.Created = append(manifest.Created, entry)
+	}
+	sort.Slice(manifest.Backups, func(i, j int) bool {
+		return manifest.Backups[i].Path < manifest.Backups[j].Path
+	})
+	for i := range manifest.Backups {
+		manifest.Backups[i].SHA256, _ = digest(filepath.Join(m.root, filepath.FromSlash(manifest.Backups[i].Path)))
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(m.root, ManifestDir, manifestPrefix+m.stamp+".json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("unable to write the manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("unable to write the manifest: %w", err)
+	}
+	return path, nil
+}
+
+// ReadManifest loads a manifest written by a planting.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// LatestManifest finds the most recent manifest written by a planting into dir.
+// Manifests are named after the UTC time of their planting, down to the
+// nanosecond, so the last name in lexical order is the latest planting.
+func LatestManifest(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, ManifestDir, manifestPrefix+"*.json"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no planting to undo in %s", filepath.Join(dir, ManifestDir))
+	}
+
+	// the timestamps sort chronologically
+	sort.Strings(matches)
+	return matches[len(matches)-1], nil
+}
+
+type undoConfig struct {
+	force bool
+}
+
+type UndoOption func(*undoConfig)
+
+// WithForce removes and restores paths even when they were modified since
+// they were planted.
+func WithForce() UndoOption {
+	return func(c *undoConfig) {
+		c.force = true
+	}
+}
+
+// UndoReport lists what Undo did. Paths are relative like those of the manifest.
+type UndoReport struct {
+	Removed  []string
+	Restored []string
+	// Kept lists created directories that now hold files seed did not plant
+	Kept []string
+}
+
+// ModifiedError is returned by Undo when planted files changed since, and
+// WithForce was not given.
+type ModifiedError struct {
+	Paths []string
+}
+
+func (e *ModifiedError) Error() string {
+	return fmt.Sprintf("modified since planting: %s", strings.Join(e.Paths, ", "))
+}
+
+// Undo reverts the planting recorded in a manifest. Created paths are removed
+// in reverse order and overwritten files are restored from their backups.
+// Directories holding files that were added since are kept. Nothing is touched
+// when a planted file was modified since, unless WithForce is given. Once
+// undone, the manifest and its backups are removed.
+func Undo(manifestPath string, opts ...UndoOption) (*UndoReport, error) {
+	cfg := &undoConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	manifest, err := ReadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	// the manifest lives in <root>/.seed
+	root := filepath.Dir(filepath.Dir(manifestPath))
+	if err := manifest.check(root); err != nil {
+		return nil, err
+	}
+
+	if !cfg.force {
+		if modified := manifest.modified(root); len(modified) > 0 {
+			return nil, &ModifiedError{Paths: modified}
+		}
+	}
+
+	report := &UndoReport{}
+	var errs []error
+
+	// a read-only directory has to be writable again before it can be emptied
+	for _, p := range manifest.Created {
+		if p.Type == "directory" {
+			os.Chmod(filepath.Join(root, filepath.FromSlash(p.Path)), 0755)
+		}
+	}
+	for i := len(manifest.Created) - 1; i >= 0; i-- {
+		p := manifest.Created[i]
+		path := filepath.Join(root, filepath.FromSlash(p.Path))
+		err := os.Remove(path)
+		switch {
+		case err == nil:
+			report.Removed = append(report.Removed, p.Path)
+		case os.IsNotExist(err):
+		case p.Type == "directory" && !isEmptyDir(path):
+			report.Kept = append(report.Kept, p.Path)
+		default:
+			errs = append(errs, err)
+		}
+	}
+
+	for _, b := range manifest.Backups {
+		if err := restoreBackup(root, b); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		report.Restored = append(report.Restored, b.Path)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return report, err
+	}
+
+	if err := os.Remove(manifestPath); err != nil {
+		return report, err
+	}
+	stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(manifestPath), manifestPrefix), ".json")
+	os.RemoveAll(filepath.Join(root, ManifestDir, "backups", stamp))
+	removeEmpty(filepath.Join(root, ManifestDir, "backups"))
+	removeEmpty(filepath.Join(root, ManifestDir))
+	return report, nil
+}
+
+// check refuses a manifest naming paths outside of root, which Undo would
+// otherwise remove or overwrite
+func (m *Manifest) check(root string) error {
+	inside := func(rel string) error {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if path == filepath.Clean(root) || !planter.Within(root, path) {
+			return fmt.Errorf("invalid manifest: %q is not inside %s", rel, root)
+		}
+		return nil
+	}
+
+	for _, p := range m.Created {
+		if err := inside(p.Path); err != nil {
+			return err
+		}
+	}
+	for _, b := range m.Backups {
+		if err := inside(b.Path); err != nil {
+			return err
+		}
+		if err := inside(b.Backup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// modified lists the planted paths whose content or type changed since
+func (m *Manifest) modified(root string) []string {
+	var modified []string
+	check := func(rel, wantType, sum string) {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info, err := os.Lstat(path)
+		if err != nil {
+			// a path that is gone needs no undoing
+			return
+		}
+		if info.IsDir() != (wantType == "directory") {
+			modified = append(modified, rel)
+			return
+		}
+		if sum == "" || info.IsDir() {
+			return
+		}
+		if got, err := digest(path); err != nil || got != sum {
+			modified = append(modified, rel)
+		}
+	}
+
+	for _, p := range m.Created {
+		check(p.Path, p.Type, p.SHA256)
+	}
+	for _, b := range m.Backups {
+		check(b.Path, "file", b.SHA256)
+	}
+	return modified
+}
+
+// restoreBackup moves a backup over the file that replaced it
+func restoreBackup(root string, b ManifestBackup) error {
+	path := filepath.Join(root, filepath.FromSlash(b.Path))
+	backup := filepath.Join(root, filepath.FromSlash(b.Backup))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to restore %s: %w", b.Path, err)
+	}
+	if err := os.Rename(backup, path); err != nil {
+		return fmt.Errorf("unable to restore %s: %w", b.Path, err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst along with its permissions
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func digest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isEmptyDir(path string) bool {
+	entries, err := os.ReadDir(path)
+	return err == nil && len(entries) == 0
+}
+
+// removeEmpty removes dir if nothing is left in it
+func removeEmpty(dir string) {
+	if isEmptyDir(dir) {
+		os.Remove(dir)
+	}
+}
@@ -0,0 +1,135 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plantWithManifest plants layout into dest over an existing README.md
+func plantWithManifest(t *testing.T, dest string, opts ...PlantOption) (*Report, error) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "project"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "project", "README.md"), []byte("mine"), 0600))
+
+	input := "project\n├── cmd\n│   └── main.go\n└── README.md\n"
+	tree, err := Parse(context.Background(), strings.NewReader(input), FormatTree)
+	require.NoError(t, err)
+	tree.Children[1].Content = "planted"
+	return Plant(context.Background(), tree, dest, append(opts, WithManifest())...)
+}
+
+func TestManifest(t *testing.T) {
+	dest := t.TempDir()
+	report, err := plantWithManifest(t, dest)
+	require.NoError(t, err)
+
+	latest, err := LatestManifest(dest)
+	require.NoError(t, err)
+	assert.Equal(t, report.Manifest, latest)
+
+	manifest, err := ReadManifest(latest)
+	require.NoError(t, err)
+	var created []string
+	for _, p := range manifest.Created {
+		created = append(created, p.Path)
+	}
+	assert.Equal(t, []string{"project/cmd", "project/cmd/main.go"}, created, "existing paths are not listed as created")
+	require.Len(t, manifest.Backups, 1)
+	assert.Equal(t, "project/README.md", manifest.Backups[0].Path)
+
+	data, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(manifest.Backups[0].Backup)))
+	require.NoError(t, err)
+	assert.Equal(t, "mine", string(data))
+}
+
+func TestUndo(t *testing.T) {
+	dest := t.TempDir()
+	report, err := plantWithManifest(t, dest)
+	require.NoError(t, err)
+
+	undone, err := Undo(report.Manifest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"project/cmd/main.go", "project/cmd"}, undone.Removed)
+	assert.Equal(t, []string{"project/README.md"}, undone.Restored)
+
+	assert.NoDirExists(t, filepath.Join(dest, "project", "cmd"))
+	data, err := os.ReadFile(filepath.Join(dest, "project", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "mine", string(data))
+	info, err := os.Stat(filepath.Join(dest, "project", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assert.NoDirExists(t, filepath.Join(dest, ManifestDir), "the manifest is removed once undone")
+	_, err = LatestManifest(dest)
+	assert.ErrorContains(t, err, "no planting to undo")
+}
+
+func TestUndoModified(t *testing.T) {
+	dest := t.TempDir()
+	report, err := plantWithManifest(t, dest)
+	require.NoError(t, err)
+
+	main := filepath.Join(dest, "project", "cmd", "main.go")
+	require.NoError(t, os.WriteFile(main, []byte("edited"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "project", "cmd", "extra.go"), nil, 0644))
+
+	_, err = Undo(report.Manifest)
+	var modified *ModifiedError
+	require.ErrorAs(t, err, &modified)
+	assert.Equal(t, []string{"project/cmd/main.go"}, modified.Paths)
+	assert.FileExists(t, main, "nothing is touched without force")
+
+	undone, err := Undo(report.Manifest, WithForce())
+	require.NoError(t, err)
+	assert.NoFileExists(t, main)
+	assert.Equal(t, []string{"project/cmd"}, undone.Kept, "files added since are kept")
+	assert.FileExists(t, filepath.Join(dest, "project", "cmd", "extra.go"))
+}
+
+func TestUndoOutsideRoot(t *testing.T) {
+	parent := t.TempDir()
+	outside := filepath.Join(parent, "outside.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("mine"), 0644))
+
+	for _, manifest := range []string{
+		`{"created":[{"path":"../outside.txt","type":"file"}]}`,
+		`{"created":[{"path":".","type":"directory"}]}`,
+		`{"backups":[{"path":"../outside.txt","backup":".seed/backups/x/outside.txt"}]}`,
+		`{"backups":[{"path":"README.md","backup":"../outside.txt"}]}`,
+	} {
+		path := filepath.Join(parent, "dest", ManifestDir, "manifest-x.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(manifest), 0644))
+
+		_, err := Undo(path, WithForce())
+		assert.ErrorContains(t, err, "invalid manifest", manifest)
+		assert.FileExists(t, outside)
+	}
+}
+
+func TestRollbackRestoresBackups(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "project", "blocked"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "project", "README.md"), []byte("mine"), 0644))
+
+	// README.md is overwritten before the file named like an existing directory fails
+	tree, err := Parse(context.Background(), strings.NewReader("project\n├── README.md\n└── blocked.txt\n"), FormatTree)
+	require.NoError(t, err)
+	tree.Children[1].Name = "blocked"
+	tree.Children[0].Content = "planted"
+
+	_, err = Plant(context.Background(), tree, dest, WithManifest(), WithRollback())
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dest, "project", "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "mine", string(data))
+	assert.NoDirExists(t, filepath.Join(dest, ManifestDir), "no manifest is left after a rollback")
+}
@@ -0,0 +1,286 @@
+package seed
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jpwallace22/seed/internal/parser"
+	"github.com/jpwallace22/seed/internal/planter"
+	"github.com/jpwallace22/seed/pkg/formats"
+	"github.com/jpwallace22/seed/pkg/logger"
+)
+
+// Report summarises a planting. Directories and Files are counted the same way
+// as the `tree -J` report the JSON parser checks, root included.
+type Report struct {
+	Directories int
+	Files       int
+	Duration    time.Duration
+	// Paths lists every planted path in seed order, see WithPaths
+	Paths []PlantedPath
+	// Manifest is the path of the manifest written for Undo, see WithManifest
+	Manifest string
+}
+
+// PlantedPath describes what planting did to a single path.
+type PlantedPath struct {
+	// Path is relative to the destination and uses forward slashes
+	Path string
+	// Action is logger.EventPlanted, EventSkipped or EventOverwritten
+	Action string
+	IsFile bool
+	Mode   os.FileMode
+	// Bytes is the size of the content written
+	Bytes int64
+}
+
+type plantConfig struct {
+	logger   logger.Logger
+	rollback bool
+	jobs     int
+	paths    bool
+	manifest bool
+	conflict Conflict
+	hooks    bool
+	hookVars map[string]string
+	hookOut  io.Writer
+	git      Git
+	gitKeep  bool
+}
+
+// Conflict decides what planting does with a file that already exists.
+// Existing directories are always planted into.
+type Conflict string
+
+const (
+	// ConflictOverwrite replaces existing files, the default
+	ConflictOverwrite Conflict = "overwrite"
+	// ConflictSkip keeps existing files as they are
+	ConflictSkip Conflict = "skip"
+	// ConflictError fails on the first existing file
+	ConflictError Conflict = "error"
+)
+
+type PlantOption func(*plantConfig)
+
+// WithLogger receives a line for every planted path. Nothing is logged by default.
+func WithLogger(l logger.Logger) PlantOption {
+	return func(c *plantConfig) {
+		c.logger = l
+	}
+}
+
+// WithRollback removes every path the planting created when it fails or is
+// cancelled, instead of leaving a half-planted tree behind. Paths that existed
+// before are never removed.
+func WithRollback() PlantOption {
+	return func(c *plantConfig) {
+		c.rollback = true
+	}
+}
+
+// WithJobs plants sibling subtrees in parallel on up to n workers, which helps
+// most on network filesystems. Parents are always created before their
+// children, and a failure is reported the same way as with a single job.
+func WithJobs(n int) PlantOption {
+	return func(c *plantConfig) {
+		c.jobs = n
+	}
+}
+
+// WithConflict sets what happens to files that already exist.
+func WithConflict(policy Conflict) PlantOption {
+	return func(c *plantConfig) {
+		c.conflict = policy
+	}
+}
+
+// WithPaths lists every planted path in Report.Paths. The list grows with the
+// tree, so it is left empty by default.
+func WithPaths() PlantOption {
+	return func(c *plantConfig) {
+		c.paths = true
+	}
+}
+
+// WithManifest writes a manifest to the ManifestDir of dest, listing the
+// created paths and backups of the files that were overwritten, so that the
+// planting can be reverted with Undo. The manifest is also written when
+// planting fails, unless WithRollback is given.
+func WithManifest() PlantOption {
+	return func(c *plantConfig) {
+		c.manifest = true
+	}
+}
+
+// planterOptions builds the options for the internal planter, appending
+// planted paths to report when WithPaths was given
+func (c *plantConfig) planterOptions(dest string, report *Report, manifest *manifestRecorder) []planter.Option {
+	opts := []planter.Option{planter.WithJobs(c.jobs)}
+	if c.conflict != "" {
+		opts = append(opts, planter.WithConflict(planter.Conflict(c.conflict)))
+	}
+	if manifest != nil {
+		opts = append(opts, planter.WithBackup(manifest.backup))
+	}
+	if !c.paths {
+		return opts
+	}
+	return append(opts, planter.WithEntries(func(e planter.Entry) {
+		path := e.Path
+		if rel, err := filepath.Rel(dest, e.Path); err == nil {
+			path = rel
+		}
+		report.Paths = append(report.Paths, PlantedPath{
+			Path:   filepath.ToSlash(path),
+			Action: e.Action,
+			IsFile: e.IsFile,
+			Mode:   e.Mode,
+			Bytes:  e.Bytes,
+		})
+	}))
+}
+
+// newManifest starts recording a manifest for dest when WithManifest was given
+func (c *plantConfig) newManifest(dest string, start time.Time) *manifestRecorder {
+	if !c.manifest {
+		return nil
+	}
+	return newManifestRecorder(dest, start)
+}
+
+// finish writes the manifest once planting is over, or rolls back when it
+// failed and WithRollback was given. It returns err, joined with anything that
+// went wrong on the way.
+func (c *plantConfig) finish(err error, created []string, manifest *manifestRecorder, report *Report) error {
+	if err != nil && c.rollback {
+		return c.rollbackOnError(err, created, manifest)
+	}
+	if manifest == nil {
+		return err
+	}
+
+	path, manifestErr := manifest.write(created, time.Now())
+	if manifestErr != nil {
+		return errors.Join(err, manifestErr)
+	}
+	report.Manifest = path
+	return err
+}
+
+// rollbackOnError removes the created paths, restores overwritten files and returns err
+func (c *plantConfig) rollbackOnError(err error, created []string, manifest *manifestRecorder) error {
+	if rollbackErr := planter.Rollback(created); rollbackErr != nil {
+		return errors.Join(err, fmt.Errorf("rollback failed: %w", rollbackErr))
+	}
+	if manifest != nil {
+		if restoreErr := manifest.restore(); restoreErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback failed: %w", restoreErr))
+		}
+	}
+	c.logger.Warn("Rolled back %d planted paths", len(created))
+	return err
+}
+
+// Plant creates the tree below dest. The root is created as a directory inside
+// dest unless it is named ".". Cancelling ctx stops before the next path.
+func Plant(ctx context.Context, tree *Tree, dest string, opts ...PlantOption) (*Report, error) {
+	cfg := &plantConfig{
+		logger: logger.Discard(),
+		jobs:   1,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.gitKeep {
+		tree = withGitKeep(tree)
+	}
+
+	var hooks []Hook
+	if cfg.hooks {
+		var err error
+		if hooks, err = renderHooks(tree.Hooks(), cfg.hookVars); err != nil {
+			return nil, err
+		}
+		if err := cfg.runHooks(ctx, hooks, HookPre, dest, tree.rootPath()); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	report := &Report{}
+	manifest := cfg.newManifest(dest, start)
+	created, err := planter.Plant(ctx, tree.Node, dest, cfg.logger, cfg.planterOptions(dest, report, manifest)...)
+	if err := cfg.finish(err, created, manifest, report); err != nil {
+		return nil, err
+	}
+	if err := cfg.runHooks(ctx, hooks, HookPost, dest, tree.rootPath()); err != nil {
+		return nil, err
+	}
+	if err := cfg.runGit(ctx, dest, tree.rootPath(), created); err != nil {
+		return nil, err
+	}
+
+	report.Directories, report.Files = tree.Counts()
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// sniffSize is how much of a stream is looked at to detect its format
+const sniffSize = 4096
+
+// PlantStream parses and plants at the same time, so inputs far larger than
+// memory can be planted. Formats whose parser cannot stream, such as YAML, are
+// parsed in full first. FormatAuto detects the format from the start of r.
+//
+// Since planting starts before the whole input has been read, an error part
+// way through leaves the paths planted so far, unless WithRollback is given.
+// WithJobs is ignored, nodes are planted in the order they are read, and so
+// are WithHooks, WithGit and WithGitKeep.
+func PlantStream(ctx context.Context, r io.Reader, format Format, dest string, opts ...PlantOption) (*Report, error) {
+	cfg := &plantConfig{
+		logger: logger.Discard(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	buffered := bufio.NewReader(r)
+	if format == FormatAuto {
+		head, _ := buffered.Peek(sniffSize)
+		format = SniffFormat(head, FormatTree)
+	}
+
+	p, err := parser.NewParser(parser.WithFormat(string(format)))
+	if err != nil {
+		return nil, err
+	}
+	streaming, ok := p.(formats.StreamParser)
+	if !ok {
+		tree, err := Parse(ctx, buffered, format)
+		if err != nil {
+			return nil, err
+		}
+		return Plant(ctx, tree, dest, opts...)
+	}
+
+	start := time.Now()
+	report := &Report{}
+	manifest := cfg.newManifest(dest, start)
+	stream := planter.NewStream(ctx, dest, cfg.logger, cfg.rollback || cfg.manifest, cfg.planterOptions(dest, report, manifest)...)
+	created, err := stream.Finish(streaming.ParseStream(ctx, buffered, stream.Emit))
+	if err := cfg.finish(err, created, manifest, report); err != nil {
+		return nil, err
+	}
+
+	report.Directories, report.Files = stream.Directories, stream.Files
+	report.Duration = time.Since(start)
+	return report, nil
+}
@@ -0,0 +1,62 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+// Status is how a path of the tree relates to what is already in the destination.
+type Status string
+
+const (
+	// StatusNew paths do not exist yet
+	StatusNew Status = "new"
+	// StatusExisting directories already exist and are planted into
+	StatusExisting Status = "existing"
+	// StatusConflict paths are files that already exist, or exist as the other
+	// kind, and are handled by the Conflict policy
+	StatusConflict Status = "conflict"
+)
+
+// PlannedPath is a node of the tree along with what planting it would meet.
+type PlannedPath struct {
+	// Path is relative to the destination and uses forward slashes. It is
+	// empty for a root named ".", which is the destination itself.
+	Path   string
+	Node   *tree.Node
+	Depth  int
+	Status Status
+}
+
+// Preview lists every node of the tree in seed order with its status against
+// dest, without changing anything on disk.
+func Preview(t *Tree, dest string) []PlannedPath {
+	var planned []PlannedPath
+	root := ""
+	if t.Name != "." {
+		root = t.Name
+	}
+
+	t.Visit(func(path string, node *tree.Node) bool {
+		rel := tree.Join(root, path)
+		depth := 0
+		if path != "" {
+			depth = strings.Count(path, "/") + 1
+		}
+
+		status := StatusNew
+		if info, err := os.Stat(filepath.Join(dest, filepath.FromSlash(rel))); err == nil {
+			status = StatusConflict
+			if info.IsDir() && !node.IsFile {
+				status = StatusExisting
+			}
+		}
+
+		planned = append(planned, PlannedPath{Path: rel, Node: node, Depth: depth, Status: status})
+		return true
+	})
+	return planned
+}
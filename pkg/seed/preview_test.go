@@ -0,0 +1,41 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jpwallace22/seed/pkg/tree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "lib"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "go.mod"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "docs"), nil, 0644))
+
+	root := tree.NewDir(".",
+		tree.NewDir("lib", tree.NewFile("lib.go")),
+		tree.NewFile("go.mod"),
+		tree.NewDir("docs", tree.NewFile("index.md")),
+	)
+
+	var got []string
+	for _, p := range Preview(NewTree(root), dest) {
+		got = append(got, p.Path+" "+string(p.Status))
+	}
+	assert.Equal(t, []string{
+		" existing",
+		"lib existing",
+		"lib/lib.go new",
+		"go.mod conflict",
+		"docs conflict",
+		"docs/index.md new",
+	}, got)
+
+	planned := Preview(NewTree(tree.NewDir("app", tree.NewDir("src", tree.NewFile("a.go")))), dest)
+	assert.Equal(t, "app/src/a.go", planned[2].Path)
+	assert.Equal(t, 2, planned[2].Depth)
+}
@@ -0,0 +1,110 @@
+// Package seed parses, plants and harvests directory trees. It is the library
+// behind the seed CLI and has no dependency on cobra.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jpwallace22/seed/internal/parser"
+	"github.com/jpwallace22/seed/pkg/tree"
+)
+
+type Format string
+
+const (
+	FormatTree     Format = "tree"
+	FormatJSON     Format = "json"
+	FormatYAML     Format = "yaml"
+	FormatMarkdown Format = "markdown"
+	FormatPaths    Format = "paths"
+)
+
+// FormatAuto detects the format from the content when parsing, see SniffFormat.
+const FormatAuto Format = ""
+
+// Tree is a parsed or harvested directory tree. The embedded root node gives
+// access to the full tree model, see package tree.
+type Tree struct {
+	*tree.Node
+}
+
+// NewTree wraps a root node built by hand or with the tree package.
+func NewTree(root *tree.Node) *Tree {
+	return &Tree{Node: root}
+}
+
+// Parse reads a tree in the given format. Markdown and paths are output only.
+// FormatAuto sniffs the content and falls back to the tree format. Cancelling
+// ctx stops the parser, including external plugins.
+func Parse(ctx context.Context, r io.Reader, format Format) (*Tree, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the tree: %w", err)
+	}
+
+	if format == FormatAuto {
+		format = SniffFormat(data, FormatTree)
+	}
+	p, err := parser.NewParser(parser.WithFormat(string(format)))
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := p.Parse(ctx, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return NewTree(root), nil
+}
+
+// DetectFormat picks a format from a file extension, falling back when it is not recognised.
+func DetectFormat(path string, fallback Format) Format {
+	return Format(parser.DetectFormat(path, string(fallback)))
+}
+
+// SniffFormat picks a format from the content, falling back when no registered format claims it.
+func SniffFormat(data []byte, fallback Format) Format {
+	return Format(parser.SniffFormat(data, string(fallback)))
+}
+
+type writeConfig struct {
+	ascii  bool
+	indent int
+}
+
+type WriteOption func(*writeConfig)
+
+// WithASCII uses `tree --charset=ascii` connectors when writing the tree format.
+func WithASCII() WriteOption {
+	return func(c *writeConfig) {
+		c.ascii = true
+	}
+}
+
+// WithIndent sets the indentation width of the JSON, YAML and markdown
+// formats, 2 by default. The tree format always indents like `tree`.
+func WithIndent(width int) WriteOption {
+	return func(c *writeConfig) {
+		c.indent = width
+	}
+}
+
+// Write renders the tree in the given format.
+func (t *Tree) Write(w io.Writer, format Format, opts ...WriteOption) error {
+	cfg := &writeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	writer, err := parser.NewWriter(
+		parser.WithFormat(string(format)),
+		parser.WithASCII(cfg.ascii),
+		parser.WithIndent(cfg.indent),
+	)
+	if err != nil {
+		return err
+	}
+	return writer.Write(w, t.Node)
+}
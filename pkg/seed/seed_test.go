@@ -0,0 +1,175 @@
+package seed
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const layout = `project
+├── cmd
+│   └── main.go
+└── README.md
+`
+
+func TestParsePlantHarvest(t *testing.T) {
+	dest := t.TempDir()
+
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatTree)
+	require.NoError(t, err)
+
+	report, err := Plant(context.Background(), tree, dest)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Directories)
+	assert.Equal(t, 2, report.Files)
+
+	assert.FileExists(t, filepath.Join(dest, "project", "cmd", "main.go"))
+	assert.FileExists(t, filepath.Join(dest, "project", "README.md"))
+
+	harvested, err := Harvest(filepath.Join(dest, "project"))
+	require.NoError(t, err)
+	assert.Equal(t, tree.Paths(), harvested.Paths())
+}
+
+func TestPlantCancelled(t *testing.T) {
+	dest := t.TempDir()
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatTree)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = Plant(ctx, tree, dest)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	entries, err := os.ReadDir(dest)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestWrite(t *testing.T) {
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatTree)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, tree.Write(&out, FormatPaths))
+	assert.Equal(t, "project/\nproject/cmd/\nproject/cmd/main.go\nproject/README.md\n", out.String())
+
+	out.Reset()
+	require.NoError(t, tree.Write(&out, FormatYAML))
+	parsed, err := Parse(context.Background(), &out, FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, tree.Paths(), parsed.Paths())
+}
+
+func TestParseUnsupportedFormat(t *testing.T) {
+	_, err := Parse(context.Background(), strings.NewReader("- project/"), FormatMarkdown)
+	assert.Error(t, err)
+}
+
+func TestParseAuto(t *testing.T) {
+	var out bytes.Buffer
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatAuto)
+	require.NoError(t, err)
+	require.NoError(t, tree.Write(&out, FormatJSON))
+
+	parsed, err := Parse(context.Background(), &out, FormatAuto)
+	require.NoError(t, err)
+	assert.Equal(t, tree.Paths(), parsed.Paths())
+}
+
+func TestParseCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Parse(ctx, strings.NewReader(layout), FormatTree)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPlantRollback(t *testing.T) {
+	dest := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "project"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "project", "b"), nil, 0644))
+
+	// b already exists as a file, so creating b/c fails after a/ was planted
+	tree, err := Parse(context.Background(), strings.NewReader("project\n├── a\n│   └── x.go\n└── b\n    └── c/\n"), FormatTree)
+	require.NoError(t, err)
+
+	_, err = Plant(context.Background(), tree, dest, WithRollback())
+	require.Error(t, err)
+
+	assert.NoDirExists(t, filepath.Join(dest, "project", "a"))
+	assert.FileExists(t, filepath.Join(dest, "project", "b"), "existing paths are kept")
+}
+
+func TestPlantStream(t *testing.T) {
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatTree)
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatTree, FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			var seedText bytes.Buffer
+			require.NoError(t, tree.Write(&seedText, format))
+
+			dest := t.TempDir()
+			report, err := PlantStream(context.Background(), &seedText, FormatAuto, dest)
+			require.NoError(t, err)
+			assert.Equal(t, 2, report.Directories)
+			assert.Equal(t, 2, report.Files)
+
+			harvested, err := Harvest(filepath.Join(dest, "project"))
+			require.NoError(t, err)
+			assert.Equal(t, tree.Paths(), harvested.Paths())
+		})
+	}
+}
+
+func TestPlantPaths(t *testing.T) {
+	tree, err := Parse(context.Background(), strings.NewReader(layout), FormatTree)
+	require.NoError(t, err)
+	want := []string{"project", "project/cmd", "project/cmd/main.go", "project/README.md"}
+
+	report, err := Plant(context.Background(), tree, t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, report.Paths, "paths are only listed with WithPaths")
+
+	report, err = Plant(context.Background(), tree, t.TempDir(), WithPaths(), WithJobs(4))
+	require.NoError(t, err)
+	var got []string
+	for _, p := range report.Paths {
+		got = append(got, p.Path)
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, PlantedPath{Path: "project/cmd/main.go", Action: "planted", IsFile: true, Mode: report.Paths[2].Mode}, report.Paths[2])
+
+	var seedText bytes.Buffer
+	require.NoError(t, tree.Write(&seedText, FormatJSON))
+	report, err = PlantStream(context.Background(), &seedText, FormatAuto, t.TempDir(), WithPaths())
+	require.NoError(t, err)
+	got = nil
+	for _, p := range report.Paths {
+		got = append(got, p.Path)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestPlantStreamRollback(t *testing.T) {
+	// the last entry is too deep, which is only noticed after cmd/ was planted
+	input := "project\n├── cmd\n│   └── main.go\n│   │   │   └── orphan\n"
+
+	dest := t.TempDir()
+	_, err := PlantStream(context.Background(), strings.NewReader(input), FormatTree, dest)
+	require.Error(t, err)
+	assert.DirExists(t, filepath.Join(dest, "project", "cmd"), "planting starts before the input is read")
+
+	dest = t.TempDir()
+	_, err = PlantStream(context.Background(), strings.NewReader(input), FormatTree, dest, WithRollback())
+	require.Error(t, err)
+	assert.NoDirExists(t, filepath.Join(dest, "project"))
+}
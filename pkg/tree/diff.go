@@ -0,0 +1,108 @@
+package tree
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	// Missing paths are in the expected tree but not the actual one
+	Missing ChangeKind = "missing"
+	// Extra paths are in the actual tree but not the expected one
+	Extra ChangeKind = "extra"
+	// Mismatched paths are a file on one side and a directory on the other
+	Mismatched ChangeKind = "mismatched"
+)
+
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Path     string     `json:"path"`
+	Expected string     `json:"expected,omitempty"`
+	Actual   string     `json:"actual,omitempty"`
+}
+
+// Diff reports how actual drifted from expected, ignoring the root names and
+// sorted by path. Changes below a missing, extra or mismatched directory are
+// folded into that directory.
+func Diff(expected, actual *Node) []Change {
+	want, got := expected.Paths(), actual.Paths()
+	var changes []Change
+
+	for p, isFile := range want {
+		actualIsFile, ok := got[p]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: Missing, Path: p, Expected: KindOf(isFile)})
+		case actualIsFile != isFile:
+			changes = append(changes, Change{Kind: Mismatched, Path: p, Expected: KindOf(isFile), Actual: KindOf(actualIsFile)})
+		}
+	}
+	for p, isFile := range got {
+		if _, ok := want[p]; !ok {
+			changes = append(changes, Change{Kind: Extra, Path: p, Actual: KindOf(isFile)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return fold(changes)
+}
+
+// fold drops changes that sit below another change
+func fold(changes []Change) []Change {
+	folded := make([]Change, 0, len(changes))
+	var parents []string
+
+	for _, change := range changes {
+		nested := false
+		for _, parent := range parents {
+			if strings.HasPrefix(change.Path, parent+"/") {
+				nested = true
+				break
+			}
+		}
+		if nested {
+			continue
+		}
+
+		folded = append(folded, change)
+		parents = append(parents, change.Path)
+	}
+	return folded
+}
+
+// Prune removes every node matching one of the glob patterns. Patterns are
+// matched against each path component, so "node_modules" drops the directory
+// and everything below it, while "build/*.o" only matches from the root.
+func (n *Node) Prune(patterns ...string) {
+	if len(patterns) == 0 {
+		return
+	}
+	n.prune("", patterns)
+}
+
+func (n *Node) prune(parent string, patterns []string) {
+	kept := n.Children[:0]
+	for _, child := range n.Children {
+		childPath := Join(parent, child.Name)
+		if matchAny(patterns, childPath) || matchAny(patterns, child.Name) {
+			continue
+		}
+		child.prune(childPath, patterns)
+		kept = append(kept, child)
+	}
+	n.Children = kept
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	expected := NewDir("seed",
+		NewDir("src", NewFile("main.go")),
+		NewDir("docs", NewFile("index.md")),
+		NewDir("config", NewFile("app.yaml")),
+		NewFile("README.md"),
+	)
+	actual := NewDir("dir",
+		NewDir("src", NewFile("main.go")),
+		NewFile("config"),
+		NewFile("README.md"),
+		NewDir("tmp", NewFile("cache")),
+	)
+
+	assert.Equal(t, []Change{
+		{Kind: Mismatched, Path: "config", Expected: "directory", Actual: "file"},
+		{Kind: Missing, Path: "docs", Expected: "directory"},
+		{Kind: Extra, Path: "tmp", Actual: "directory"},
+	}, Diff(expected, actual))
+
+	assert.Empty(t, Diff(expected, expected.Clone()))
+}
+
+func TestPrune(t *testing.T) {
+	root := NewDir("root",
+		NewDir("node_modules", NewDir("left", NewFile("index.js"))),
+		NewDir("build", NewFile("main.o"), NewFile("keep.txt")),
+		NewDir("src", NewFile("main.o")),
+	)
+	root.Prune("node_modules", "build/*.o")
+
+	assert.Equal(t, map[string]bool{
+		"build":          false,
+		"build/keep.txt": true,
+		"src":            false,
+		"src/main.o":     true,
+	}, root.Paths())
+}
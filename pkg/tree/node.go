@@ -0,0 +1,320 @@
+// Package tree is the directory tree model shared by every seed format.
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Node is a file or directory. The root's name is not part of any path, so
+// paths handed to Find, Insert and Remove are relative to the node they are
+// called on and separated by slashes.
+type Node struct {
+	Name     string
+	IsFile   bool
+	Children []*Node
+	// Mode is zero unless the seed sets permissions explicitly
+	Mode    os.FileMode
+	Content string
+	Comment string
+	Hooks   Hooks
+	// Line is where the node was read in the seed, zero when it was not parsed.
+	// It is not compared by Equal.
+	Line int
+}
+
+// Hooks are shell commands a seed runs around planting. The root's hooks apply
+// to the whole seed, those of other nodes to that node.
+type Hooks struct {
+	Pre  []string
+	Post []string
+}
+
+// Empty reports whether there are no hooks.
+func (h Hooks) Empty() bool {
+	return len(h.Pre) == 0 && len(h.Post) == 0
+}
+
+func (h Hooks) clone() Hooks {
+	return Hooks{
+		Pre:  append([]string(nil), h.Pre...),
+		Post: append([]string(nil), h.Post...),
+	}
+}
+
+// NewDir returns a directory node with the given children.
+func NewDir(name string, children ...*Node) *Node {
+	return &Node{Name: name, Children: children}
+}
+
+// NewFile returns a file node.
+func NewFile(name string) *Node {
+	return &Node{Name: name, IsFile: true}
+}
+
+// SkipDir can be returned from a WalkFunc to skip the children of a node.
+var SkipDir = errors.New("skip this directory")
+
+// WalkFunc is called for every node, root first. The root's path is "".
+type WalkFunc func(path string, node *Node) error
+
+// Walk visits the tree depth first in child order. Returning SkipDir skips the
+// node's children, any other error stops the walk and is returned.
+func (n *Node) Walk(fn WalkFunc) error {
+	err := n.walk("", fn)
+	if errors.Is(err, SkipDir) {
+		return nil
+	}
+	return err
+}
+
+func (n *Node) walk(path string, fn WalkFunc) error {
+	if err := fn(path, n); err != nil {
+		return err
+	}
+
+	for _, child := range n.Children {
+		err := child.walk(Join(path, child.Name), fn)
+		if err != nil && !errors.Is(err, SkipDir) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Visit is a simpler Walk that descends into a node's children while fn returns true.
+func (n *Node) Visit(fn func(path string, node *Node) bool) {
+	_ = n.Walk(func(path string, node *Node) error {
+		if !fn(path, node) {
+			return SkipDir
+		}
+		return nil
+	})
+}
+
+// CheckName reports names that would not land where the tree says once
+// planted: empty names, . and .., and names containing a path separator. A
+// root may be named . to stand for the destination itself.
+func CheckName(name string, isRoot bool) error {
+	switch {
+	case name == "":
+		return errors.New("a name is required")
+	case name == "." && isRoot:
+		return nil
+	case name == "." || name == "..":
+		return fmt.Errorf("%q is not a valid name", name)
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("%q cannot contain a path separator", name)
+	}
+	return nil
+}
+
+// Join builds a slash separated path, treating "" as the root.
+func Join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+// Child returns the direct child with the given name.
+func (n *Node) Child(name string) *Node {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Find returns the node at path, or nil when there is none. An empty path is the node itself.
+func (n *Node) Find(path string) *Node {
+	current := n
+	for _, part := range split(path) {
+		current = current.Child(part)
+		if current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// Insert adds a node at path, creating any missing parent directories. An
+// existing node of the same type is returned as is.
+func (n *Node) Insert(path string, isFile bool) (*Node, error) {
+	parts := split(path)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("a path is required")
+	}
+
+	current := n
+	for i, part := range parts {
+		if current.IsFile {
+			return nil, fmt.Errorf("cannot insert %s: %s is a file", path, strings.Join(parts[:i], "/"))
+		}
+
+		last := i == len(parts)-1
+		child := current.Child(part)
+		if child == nil {
+			child = &Node{Name: part, IsFile: last && isFile}
+			current.Children = append(current.Children, child)
+		} else if last && child.IsFile != isFile {
+			return nil, fmt.Errorf("cannot insert %s: it already exists as a %s", path, child.Kind())
+		}
+		current = child
+	}
+	return current, nil
+}
+
+// Remove deletes the node at path along with its children, reporting whether it existed.
+func (n *Node) Remove(path string) bool {
+	parts := split(path)
+	if len(parts) == 0 {
+		return false
+	}
+
+	parent := n.Find(strings.Join(parts[:len(parts)-1], "/"))
+	if parent == nil {
+		return false
+	}
+
+	name := parts[len(parts)-1]
+	for i, child := range parent.Children {
+		if child.Name == name {
+			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Merge adds other's children to n, merging directories that exist on both sides.
+// Files from other replace files in n, and a file meeting a directory is an error.
+func (n *Node) Merge(other *Node) error {
+	return n.merge("", other)
+}
+
+func (n *Node) merge(path string, other *Node) error {
+	for _, theirs := range other.Children {
+		childPath := Join(path, theirs.Name)
+		ours := n.Child(theirs.Name)
+		switch {
+		case ours == nil:
+			n.Children = append(n.Children, theirs.Clone())
+		case ours.IsFile != theirs.IsFile:
+			return fmt.Errorf("cannot merge %s: %s in one tree and %s in the other", childPath, ours.Kind(), theirs.Kind())
+		case ours.IsFile:
+			*ours = *theirs.Clone()
+		default:
+			if err := ours.merge(childPath, theirs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the node.
+func (n *Node) Clone() *Node {
+	clone := *n
+	clone.Hooks = n.Hooks.clone()
+	clone.Children = make([]*Node, len(n.Children))
+	for i, child := range n.Children {
+		clone.Children[i] = child.Clone()
+	}
+	return &clone
+}
+
+// Sort orders every level of the tree with directories first, then alphabetically.
+func (n *Node) Sort() {
+	n.SortFunc(func(a, b *Node) bool {
+		if a.IsFile != b.IsFile {
+			return !a.IsFile
+		}
+		return a.Name < b.Name
+	})
+}
+
+// SortFunc orders every level of the tree with less, keeping equal nodes in place.
+func (n *Node) SortFunc(less func(a, b *Node) bool) {
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		return less(n.Children[i], n.Children[j])
+	})
+	for _, child := range n.Children {
+		child.SortFunc(less)
+	}
+}
+
+// Equal reports whether both trees have the same nodes, metadata and child order.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	if n.Name != other.Name || n.IsFile != other.IsFile || n.Mode != other.Mode ||
+		n.Content != other.Content || n.Comment != other.Comment ||
+		!slices.Equal(n.Hooks.Pre, other.Hooks.Pre) || !slices.Equal(n.Hooks.Post, other.Hooks.Post) ||
+		len(n.Children) != len(other.Children) {
+		return false
+	}
+
+	for i := range n.Children {
+		if !n.Children[i].Equal(other.Children[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Counts returns the number of directories and files, the node itself included.
+func (n *Node) Counts() (directories int, files int) {
+	n.Visit(func(_ string, node *Node) bool {
+		if node.IsFile {
+			files++
+		} else {
+			directories++
+		}
+		return true
+	})
+	return directories, files
+}
+
+// Paths flattens the tree into paths relative to the node, mapped to whether
+// each path is a file. The node itself is not included.
+func (n *Node) Paths() map[string]bool {
+	paths := make(map[string]bool)
+	n.Visit(func(path string, node *Node) bool {
+		if path != "" {
+			paths[path] = node.IsFile
+		}
+		return true
+	})
+	return paths
+}
+
+// Kind is "file" or "directory".
+func (n *Node) Kind() string {
+	return KindOf(n.IsFile)
+}
+
+// KindOf is the Kind of a node that is a file or not, for path sets such as
+// those of Paths.
+func KindOf(isFile bool) string {
+	if isFile {
+		return "file"
+	}
+	return "directory"
+}
+
+func split(path string) []string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" && part != "." {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,152 @@
+package tree
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sample() *Node {
+	return NewDir("project",
+		NewDir("src",
+			NewFile("main.go"),
+			NewDir("utils", NewFile("helper.go")),
+		),
+		NewFile("README.md"),
+	)
+}
+
+func TestWalk(t *testing.T) {
+	var visited []string
+	err := sample().Walk(func(path string, node *Node) error {
+		visited = append(visited, path)
+		if node.Name == "utils" {
+			return SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "src", "src/main.go", "src/utils", "README.md"}, visited)
+
+	stop := errors.New("stop")
+	err = sample().Walk(func(path string, node *Node) error {
+		if path == "src/main.go" {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestVisit(t *testing.T) {
+	var visited []string
+	sample().Visit(func(path string, node *Node) bool {
+		visited = append(visited, path)
+		return path != "src"
+	})
+	assert.Equal(t, []string{"", "src", "README.md"}, visited)
+}
+
+func TestFind(t *testing.T) {
+	root := sample()
+	assert.Equal(t, "helper.go", root.Find("src/utils/helper.go").Name)
+	assert.Equal(t, root, root.Find(""))
+	assert.Nil(t, root.Find("src/missing"))
+	assert.Nil(t, root.Find("README.md/nested"))
+}
+
+func TestInsert(t *testing.T) {
+	root := sample()
+
+	node, err := root.Insert("docs/guides/intro.md", true)
+	require.NoError(t, err)
+	assert.True(t, node.IsFile)
+	assert.False(t, root.Find("docs/guides").IsFile)
+
+	existing, err := root.Insert("src/main.go", true)
+	require.NoError(t, err)
+	assert.Same(t, root.Find("src/main.go"), existing)
+
+	_, err = root.Insert("src/main.go", false)
+	assert.Error(t, err, "type conflict should error")
+
+	_, err = root.Insert("README.md/nested", true)
+	assert.Error(t, err, "inserting below a file should error")
+}
+
+func TestRemove(t *testing.T) {
+	root := sample()
+	assert.True(t, root.Remove("src/utils"))
+	assert.Nil(t, root.Find("src/utils/helper.go"))
+	assert.False(t, root.Remove("src/utils"))
+	assert.False(t, root.Remove(""))
+}
+
+func TestMerge(t *testing.T) {
+	root := sample()
+	other := NewDir("other",
+		NewDir("src", NewFile("extra.go"), &Node{Name: "main.go", IsFile: true, Comment: "replaced"}),
+		NewDir("docs"),
+	)
+
+	require.NoError(t, root.Merge(other))
+	assert.NotNil(t, root.Find("src/extra.go"))
+	assert.NotNil(t, root.Find("src/utils/helper.go"))
+	assert.NotNil(t, root.Find("docs"))
+	assert.Equal(t, "replaced", root.Find("src/main.go").Comment)
+
+	// merged nodes are copies
+	other.Find("docs").Name = "changed"
+	assert.NotNil(t, root.Find("docs"))
+
+	err := root.Merge(NewDir("bad", NewDir("src", NewDir("main.go"))))
+	assert.ErrorContains(t, err, "src/main.go")
+}
+
+func TestSort(t *testing.T) {
+	root := NewDir("root", NewFile("b.txt"), NewDir("z"), NewFile("a.txt"), NewDir("m"))
+	root.Sort()
+
+	var names []string
+	for _, child := range root.Children {
+		names = append(names, child.Name)
+	}
+	assert.Equal(t, []string{"m", "z", "a.txt", "b.txt"}, names)
+}
+
+func TestEqualAndClone(t *testing.T) {
+	root := sample()
+	clone := root.Clone()
+	assert.True(t, root.Equal(clone))
+
+	clone.Find("src/main.go").Comment = "entry"
+	assert.False(t, root.Equal(clone))
+	assert.Empty(t, root.Find("src/main.go").Comment, "clone must be deep")
+}
+
+func TestCountsAndPaths(t *testing.T) {
+	dirs, files := sample().Counts()
+	assert.Equal(t, 3, dirs)
+	assert.Equal(t, 3, files)
+
+	assert.Equal(t, map[string]bool{
+		"src":                 false,
+		"src/main.go":         true,
+		"src/utils":           false,
+		"src/utils/helper.go": true,
+		"README.md":           true,
+	}, sample().Paths())
+}
+
+func TestCheckName(t *testing.T) {
+	assert.NoError(t, CheckName("main.go", false))
+	assert.NoError(t, CheckName(".", true))
+	assert.NoError(t, CheckName("my #1.txt", false))
+
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`, "../escaped.txt"} {
+		assert.Error(t, CheckName(name, false), name)
+	}
+	assert.Error(t, CheckName("..", true))
+}